@@ -2,15 +2,34 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	backendmodule "github.com/sohel902833/go_super_cli/src/backend-module"
+	"github.com/sohel902833/go_super_cli/src/config"
+	"github.com/sohel902833/go_super_cli/src/diff"
+	"github.com/sohel902833/go_super_cli/src/fields"
+	frontendmodule "github.com/sohel902833/go_super_cli/src/frontend-module"
+	"github.com/sohel902833/go_super_cli/src/manifest"
+	"github.com/sohel902833/go_super_cli/src/markers"
+	"github.com/sohel902833/go_super_cli/src/openapi"
+	"github.com/sohel902833/go_super_cli/src/render"
+	"github.com/sohel902833/go_super_cli/src/tsedit"
 	"github.com/sohel902833/go_super_cli/src/types"
+	"github.com/sohel902833/go_super_cli/src/verify"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 
@@ -18,14 +37,46 @@ var (
 	configFile string
 	dryRun     bool
 	verbose    bool
+
+	initName           string
+	initPort           string
+	initDatabaseURL    string
+	initPackageManager string
+	assumeYes          bool
+	verifyInit         bool
+
+	fromFile     string
+	sampleFile   string
+	onConflict   string
+	openapiFile  string
+	forceRemove  bool
+	updateFields string
+
+	// stdin is shared by every prompt, so answers piped in are not lost
+	// to a second buffered reader.
+	stdin = bufio.NewReader(os.Stdin)
+
+	currentConfig *types.ProjectConfig
+	configSource  string
+	projectRoot   string
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "super",
 	Short: "Super CLI - Dynamic CRUD Code Generator",
 	Long:  `A powerful CLI tool that generates CRUD boilerplate code dynamically based on configurable instructions.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if onConflict != "" && !slices.Contains(conflictPolicies, onConflict) {
+			return fmt.Errorf("invalid --on-conflict %q (expected %s)", onConflict, strings.Join(conflictPolicies, ", "))
+		}
+		return nil
+	},
 }
 
+// conflictPolicies are the values of --on-conflict: what to do when a file
+// about to be generated already exists with other content.
+var conflictPolicies = []string{"skip", "overwrite", "prompt", "backup", "fail"}
+
 var createCmd = &cobra.Command{
 	Use:   "create [bm|fm]",
 	Short: "Create a new module",
@@ -41,68 +92,163 @@ var createCmd = &cobra.Command{
 	},
 }
 
+var removeCmd = &cobra.Command{
+	Use:   "remove bm [name]",
+	Short: "Remove a generated module",
+	Long: `Delete the files generated for a backend module and take its registrations out of the shared files.
+The planned deletions and edits are shown first and need confirming, unless --yes is given; --dry-run only shows them.
+Files edited since they were generated are kept unless --force is given.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if args[0] != "bm" {
+			fmt.Println("Error: only backend modules (bm) can be removed")
+			return
+		}
+		handleRemove(args[0], args[1])
+	},
+}
+
+var updateCmd = &cobra.Command{
+	Use:   "update bm [name]",
+	Short: "Regenerate a module with new fields",
+	Long: `Render a backend module again, with the fields given by --fields or else the ones it was generated with, and merge the result into its files.
+Edits made since the last generation are kept; where they clash with the regenerated code, both versions are left between git-style conflict markers.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if args[0] != "bm" {
+			fmt.Println("Error: only backend modules (bm) can be updated")
+			return
+		}
+		handleUpdate(args[0], args[1])
+	},
+}
+
 var uploadCmd = &cobra.Command{
 	Use:   "upload [filepath]",
-	Short: "Bulk create modules from JSON file",
-	Long:  `Upload a JSON file containing module definitions and create multiple modules at once.`,
-	Args:  cobra.ExactArgs(1),
+	Short: "Bulk create modules from a JSON or YAML file",
+	Long: `Upload a JSON or YAML file containing module definitions and create multiple modules at once.
+Each module gives its fields either as a "modelProperties" string or as a structured "fields" list.
+With --openapi, every object schema under components.schemas of an OpenAPI 3 document becomes a module.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if openapiFile != "" {
+			if len(args) > 0 {
+				fmt.Println("Error: give either a module file or --openapi, not both")
+				return
+			}
+			handleOpenAPIUpload(openapiFile)
+			return
+		}
+		if len(args) == 0 {
+			fmt.Println("Error: a module file or --openapi is required")
+			return
+		}
 		filepath := args[0]
 		handleBulkUpload(filepath)
 	},
 }
 
 var initCmd = &cobra.Command{
-	Use:   "init [bp|fp]",
+	Use:   "init [bp|fp] [name]",
 	Short: "Initialize a new project with base structure",
-	Long:  `Create a new backend project (bp) or frontend project (fp) interactively.`,
+	Long: `Create a new backend project (bp) or frontend project (fp) interactively.
+The project is created in a new directory named after the project; pass "." to use the current directory.`,
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectType := args[0]
 		if projectType != "bp" && projectType != "fp" {
 			fmt.Println("Error: Project type must be 'bp' or 'fp'")
 			return
 		}
+		if len(args) == 2 {
+			if initName != "" && initName != args[1] {
+				fmt.Println("Error: project name given both as argument and --name")
+				return
+			}
+			initName = args[1]
+		}
 		handleInit(projectType)
 	},
 }
 
-// var configCmd = &cobra.Command{
-// 	Use:   "config",
-// 	Short: "Manage configuration templates",
-// 	Long:  `Load, save, and manage custom template configurations.`,
-// }
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage configuration templates",
+	Long:  `Load, save, and manage custom template configurations.`,
+}
 
-// var loadConfigCmd = &cobra.Command{
-// 	Use:   "load [filepath]",
-// 	Short: "Load custom configuration from file",
-// 	Args:  cobra.ExactArgs(1),
-// 	Run: func(cmd *cobra.Command, args []string) {
-// 		handleLoadConfig(args[0])
-// 	},
-// }
+var loadConfigCmd = &cobra.Command{
+	Use:   "load [filepath]",
+	Short: "Load custom configuration from file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleLoadConfig(args[0])
+	},
+}
 
-// var exportConfigCmd = &cobra.Command{
-// 	Use:   "export [filepath]",
-// 	Short: "Export current configuration to file",
-// 	Args:  cobra.ExactArgs(1),
-// 	Run: func(cmd *cobra.Command, args []string) {
-// 		handleExportConfig(args[0])
-// 	},
-// }
+var exportConfigCmd = &cobra.Command{
+	Use:   "export [filepath]",
+	Short: "Export current configuration to file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleExportConfig(args[0])
+	},
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate [filepath]",
+	Short: "Check a configuration file for mistakes",
+	Long:  `Report duplicate file paths, unknown {{TOKENS}} and invalid update positions in a configuration file.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleValidateConfig(args[0])
+	},
+}
+
+var showConfigCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration currently in effect",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleShowConfig()
+	},
+}
 
 func init() {
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(uploadCmd)
+	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(initCmd)
-	// rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(configCmd)
 
-	// configCmd.AddCommand(loadConfigCmd)
-	// configCmd.AddCommand(exportConfigCmd)
+	configCmd.AddCommand(loadConfigCmd)
+	configCmd.AddCommand(exportConfigCmd)
+	configCmd.AddCommand(validateConfigCmd)
+	configCmd.AddCommand(showConfigCmd)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "Custom config file path")
-	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "d", false, "Preview changes without creating files")
+	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "d", false, "Preview changes as unified diffs without writing files")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+
+	initCmd.Flags().StringVar(&initName, "name", "", "Project name (also used as the directory name)")
+	initCmd.Flags().StringVar(&initPort, "port", "", "Port the generated app listens on")
+	initCmd.Flags().StringVar(&initDatabaseURL, "db-url", "", "MongoDB connection string (bp only)")
+	initCmd.Flags().StringVar(&initPackageManager, "package-manager", "", "Package manager: npm, yarn, pnpm or bun")
+	initCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Accept defaults instead of prompting")
+	createCmd.Flags().StringVar(&fromFile, "from", "", "Read the fields from an existing Mongoose model or TypeScript interface file; module files that already exist are kept unless --on-conflict says otherwise")
+	createCmd.Flags().StringVar(&sampleFile, "sample", "", "Infer the fields from an example JSON object or array of objects")
+
+	for _, cmd := range []*cobra.Command{createCmd, uploadCmd, initCmd} {
+		cmd.Flags().StringVar(&onConflict, "on-conflict", "", "What to do with files that already exist and differ: skip, overwrite, prompt (show a diff and ask), backup (keep the old file as .bak) or fail (default)")
+	}
+	removeCmd.Flags().BoolVar(&forceRemove, "force", false, "Also delete files that were edited after generation")
+	removeCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Remove without asking for confirmation")
+	updateCmd.Flags().StringVar(&updateFields, "fields", "", "The module's new field list, in the create format (default: the fields it was generated with)")
+	uploadCmd.Flags().StringVar(&openapiFile, "openapi", "", "Create a module for every object schema in an OpenAPI 3 document (YAML or JSON)")
+
+	initCmd.Flags().BoolVar(&verifyInit, "verify", false, "Check that every relative and @/ import in the generated files resolves, without writing anything")
 }
 
 func main() {
@@ -117,58 +263,571 @@ func handleCreate(moduleType string) {
 	var moduleName string
 	var fields string
 
-	reader := bufio.NewReader(os.Stdin)
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	reader := stdin
+
+	var sourceFields []types.Field
+	defaultName := ""
+	if fromFile != "" {
+		var err error
+		if sourceFields, err = readFieldsFrom(fromFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		defaultName = strings.SplitN(filepath.Base(fromFile), ".", 2)[0]
+		if onConflict == "" {
+			onConflict = "skip"
+		}
+	}
+	if sampleFile != "" {
+		if fromFile != "" {
+			fmt.Println("Error: give either --from or --sample, not both")
+			return
+		}
+		var err error
+		if sourceFields, err = readFieldsFromSample(sampleFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if answer := strings.ToLower(prompt(reader, "Generate the module with these fields? (Y/n)", "")); answer != "" && answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return
+		}
+		defaultName = strings.SplitN(filepath.Base(sampleFile), ".", 2)[0]
+	}
 
-	fmt.Print("Enter module name: ")
+	if defaultName != "" {
+		fmt.Printf("Enter module name (%s): ", defaultName)
+	} else {
+		fmt.Print("Enter module name: ")
+	}
 	moduleName, _ = reader.ReadString('\n')
 	moduleName = strings.TrimSpace(moduleName)
+	if moduleName == "" {
+		moduleName = defaultName
+	}
 
 	if moduleName == "" {
 		fmt.Println("Error: module name is required")
 		return
 	}
 
-	fmt.Print("Enter fields (optional, format: name@S@R,email@S@R): ")
-	fields, _ = reader.ReadString('\n')
-	fields = strings.TrimSpace(fields)
+	if sourceFields == nil {
+		fmt.Print("Enter fields (optional, format: name@S@R,status@E(active|draft),tags@S[]): ")
+		fields, _ = reader.ReadString('\n')
+		fields = strings.TrimSpace(fields)
+	}
 
 	module := types.Module{
 		ModuleName:      moduleName,
 		ModelProperties: fields,
 	}
+	parsed, err := moduleFields(module)
+	if err != nil {
+		printFieldError(err)
+		return
+	}
+	if sourceFields != nil {
+		parsed = sourceFields
+	}
 
 	if dryRun {
-		fmt.Println("\n🔍 DRY RUN MODE - No files will be created\n")
+		fmt.Print("\n🔍 DRY RUN MODE - No files will be created\n\n")
+	}
+
+	if !generateModule(moduleType, moduleName, parsed) {
+		return
 	}
 
-	generateModule(moduleType, module)
-	
 	if !dryRun {
 		fmt.Printf("\n✓ Module '%s' created successfully!\n", moduleName)
 	}
 }
 
+func handleRemove(moduleType, moduleName string) {
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if !moduleNamePattern.MatchString(moduleName) {
+		fmt.Printf("Error: invalid module name %q\n", moduleName)
+		return
+	}
+
+	instructions, updates, err := getInstructions(moduleType)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		return
+	}
+	generated, err := manifest.Load(projectRoot)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	entry := generated.Module(moduleName)
+	moduleFields := generatedFields(instructions, moduleName)
+	if entry != nil {
+		if moduleFields, err = fields.FromDefinitions(entry.Fields, customTypes()...); err != nil {
+			fmt.Printf("Error: %s: %v\n", manifest.FileName, err)
+			return
+		}
+	}
+	ctx := buildTemplateContext(moduleName, moduleFields)
+
+	// Work out which generated files still match what the generator wrote,
+	// by the hash in the manifest or else by rendering them again
+	type generatedFile struct {
+		path   string
+		edited bool
+	}
+	var files []generatedFile
+	for _, instruction := range instructions {
+		filePath, err := renderTemplate(instruction.FilePath, instruction.FilePath, ctx)
+		if err != nil {
+			fmt.Printf("Error rendering path %s: %v\n", instruction.FilePath, err)
+			return
+		}
+		existing, err := os.ReadFile(resolvePath(projectRoot, filePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if recorded := entry.File(filePath); recorded != nil {
+			files = append(files, generatedFile{filePath, manifest.Hash(string(existing)) != recorded.Hash})
+			continue
+		}
+		expected, err := renderTemplate(filePath, instruction.Content, ctx)
+		if err != nil {
+			fmt.Printf("Error rendering %s: %v\n", filePath, err)
+			return
+		}
+		files = append(files, generatedFile{filePath, string(existing) != expected})
+	}
+
+	// Work out every edit to the shared files before changing anything
+	pending := map[string]string{}
+	var edits []*removal
+	for _, update := range updates {
+		filePath, err := renderTemplate(update.FilePath, update.FilePath, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering path %s: %v\n", update.FilePath, err)
+			continue
+		}
+		content, err := renderTemplate(filePath, update.Content, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering update for %s: %v\n", filePath, err)
+			continue
+		}
+		block := blockID(update, ctx)
+		edit := planRemoval(pending, filePath, "block "+block, func(file string) (string, bool, error) {
+			updated, count, err := markers.Remove(file, block)
+			if err != nil || count > 0 {
+				return updated, count > 0, err
+			}
+			updated, found := markers.RemoveCopy(file, content)
+			return updated, found, nil
+		})
+		if edit != nil {
+			edits = append(edits, edit)
+		}
+	}
+	if moduleType == "bm" {
+		edit := planRemoval(pending, openapi.FileName, "paths under /"+ctx.LowerCaseModuleName, func(file string) (string, bool, error) {
+			updated, changed, err := openapi.Remove([]byte(file), ctx)
+			return string(updated), changed, err
+		})
+		if edit != nil {
+			edits = append(edits, edit)
+		}
+	}
+
+	// Preview
+	if dryRun {
+		fmt.Print("\n🔍 DRY RUN MODE - No files will be changed\n\n")
+	}
+	fmt.Println("🗑  Files to delete:")
+	if len(files) == 0 {
+		fmt.Println("  • No generated files found")
+	}
+	for _, file := range files {
+		if file.edited {
+			fmt.Printf("  • %s (edited)\n", file.path)
+		} else {
+			fmt.Printf("  • %s\n", file.path)
+		}
+	}
+	fmt.Println("\n🔧 Changes to shared files:")
+	if len(edits) == 0 {
+		fmt.Println("  • None")
+	}
+	for _, edit := range edits {
+		fmt.Printf("  • Remove from %s: %s\n", edit.path, edit.what)
+		printDiff(edit.path, edit.before, edit.after, false)
+	}
+
+	// Edited files are only deleted with --force, after the user has seen them
+	edited := 0
+	for _, file := range files {
+		if file.edited {
+			edited++
+		}
+	}
+	if edited > 0 && !forceRemove {
+		fmt.Printf("\n%d file(s) marked (edited) were changed after they were generated.\n", edited)
+		if !dryRun {
+			fmt.Println("Nothing was removed.")
+		}
+		fmt.Println("Re-run with --force to delete them anyway.")
+		return
+	}
+	if dryRun {
+		return
+	}
+	if len(files) == 0 && len(edits) == 0 {
+		fmt.Printf("\nNothing to remove for module '%s'.\n", moduleName)
+		return
+	}
+	if !assumeYes {
+		answer := strings.ToLower(prompt(stdin, fmt.Sprintf("\nRemove module '%s'? (y/N)", moduleName), ""))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled. Nothing was removed.")
+			return
+		}
+	}
+
+	fmt.Println()
+	var dirs []string
+	for _, file := range files {
+		if err := os.Remove(resolvePath(projectRoot, file.path)); err != nil {
+			fmt.Printf("  ✗ Error deleting %s: %v\n", file.path, err)
+			continue
+		}
+		fmt.Printf("  ✓ Deleted: %s\n", file.path)
+		dirs = append(dirs, filepath.Dir(resolvePath(projectRoot, file.path)))
+	}
+	// Drop directories the module leaves empty
+	for _, dir := range dirs {
+		os.Remove(dir)
+	}
+	for _, edit := range edits {
+		if err := os.WriteFile(resolvePath(projectRoot, edit.path), []byte(edit.after), 0644); err != nil {
+			fmt.Printf("  ✗ Error updating %s: %v\n", edit.path, err)
+			continue
+		}
+		fmt.Printf("  ✓ Removed from %s: %s\n", edit.path, edit.what)
+	}
+
+	if entry != nil {
+		generated.RemoveModule(moduleName)
+		if err := generated.Save(projectRoot); err != nil {
+			fmt.Printf("  ✗ Error updating %s: %v\n", manifest.FileName, err)
+		}
+	}
+	fmt.Printf("\n✓ Module '%s' removed.\n", moduleName)
+}
+
+// handleUpdate regenerates a module and three-way merges each file: the
+// base is the module as last generated, rendered again from the fields in
+// the manifest, "current" is the file on disk and "generated" the new
+// rendering.
+func handleUpdate(moduleType, moduleName string) {
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if !moduleNamePattern.MatchString(moduleName) {
+		fmt.Printf("Error: invalid module name %q\n", moduleName)
+		return
+	}
+
+	instructions, updates, err := getInstructions(moduleType)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		return
+	}
+	generated, err := manifest.Load(projectRoot)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	entry := generated.Module(moduleName)
+	var baseFields []types.Field
+	if entry != nil {
+		if baseFields, err = fields.FromDefinitions(entry.Fields, customTypes()...); err != nil {
+			fmt.Printf("Error: %s: %v\n", manifest.FileName, err)
+			return
+		}
+	} else {
+		fmt.Printf("⚠ %s is not in %s; the fields read from its model are used as the merge base\n", moduleName, manifest.FileName)
+		baseFields = generatedFields(instructions, moduleName)
+	}
+
+	newFields := baseFields
+	if updateFields != "" {
+		if newFields, err = moduleFields(types.Module{ModuleName: moduleName, ModelProperties: updateFields}); err != nil {
+			printFieldError(err)
+			return
+		}
+	}
+	baseCtx := buildTemplateContext(moduleName, baseFields)
+	ctx := buildTemplateContext(moduleName, newFields)
+
+	if dryRun {
+		fmt.Print("\n🔍 DRY RUN MODE - No files will be changed\n\n")
+	}
+
+	fmt.Println("🔀 Merging files:")
+	record := &manifest.Entry{Files: []manifest.File{}}
+	conflicts := 0
+	templatesChanged := false
+	// A file that is not rewritten keeps its earlier record, so the module
+	// still owns it
+	keepRecord := func(filePath string) {
+		if recorded := entry.File(filePath); recorded != nil {
+			record.Files = append(record.Files, *recorded)
+		}
+	}
+	for _, instruction := range instructions {
+		filePath, err := renderTemplate(instruction.FilePath, instruction.FilePath, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering path %s: %v\n", instruction.FilePath, err)
+			continue
+		}
+		base, err := renderTemplate(filePath, instruction.Content, baseCtx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering %s: %v\n", filePath, err)
+			keepRecord(filePath)
+			continue
+		}
+		next, err := renderTemplate(filePath, instruction.Content, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering %s: %v\n", filePath, err)
+			keepRecord(filePath)
+			continue
+		}
+		if recorded := entry.File(filePath); recorded != nil && recorded.Hash != manifest.Hash(base) {
+			templatesChanged = true
+		}
+
+		path := resolvePath(projectRoot, filePath)
+		current, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Printf("  ✗ Error reading %s: %v\n", filePath, err)
+			keepRecord(filePath)
+			continue
+		}
+
+		merged, action, status, fileConflicts := next, "update", "Updated", 0
+		switch {
+		case os.IsNotExist(err):
+			action, status = "create", "Created"
+		case string(current) == next:
+			fmt.Printf("  • Up to date: %s\n", filePath)
+			record.Files = append(record.Files, manifest.File{Path: filePath, Hash: manifest.Hash(next)})
+			continue
+		case string(current) != base:
+			merged, fileConflicts = diff.Merge(base, string(current), next, diff.Labels{Ours: "current", Theirs: "generated"})
+			action, status = "merge", "Merged"
+		}
+		conflicts += fileConflicts
+
+		if dryRun {
+			if fileConflicts > 0 {
+				fmt.Printf("  [DRY RUN] Would merge with %d conflict(s): %s\n", fileConflicts, filePath)
+			} else {
+				fmt.Printf("  [DRY RUN] Would %s: %s\n", action, filePath)
+			}
+			printDiff(filePath, string(current), merged, action == "create")
+			continue
+		}
+		if err := createFile(path, merged); err != nil {
+			fmt.Printf("  ✗ Error writing %s: %v\n", filePath, err)
+			keepRecord(filePath)
+			continue
+		}
+		if fileConflicts > 0 {
+			fmt.Printf("  ⚠ Conflicts: %s (%d)\n", filePath, fileConflicts)
+		} else {
+			fmt.Printf("  ✓ %s: %s\n", status, filePath)
+		}
+		record.Files = append(record.Files, manifest.File{Path: filePath, Hash: manifest.Hash(next)})
+	}
+	if templatesChanged {
+		fmt.Println("  ⚠ The templates changed since the module was generated, so the merge base is only approximate")
+	}
+
+	record.Updates = runUpdates(projectRoot, updates, ctx, nil)
+	if moduleType == "bm" {
+		updateOpenAPI(projectRoot, ctx)
+	}
+
+	record.Name = moduleName
+	record.Type = moduleType
+	record.Fields = fields.Definitions(newFields)
+	record.Templates = manifest.Templates{Set: templateSet(moduleType), Version: manifest.TemplateVersion(instructions, updates)}
+	recordGeneration(projectRoot, record)
+
+	switch {
+	case dryRun:
+	case conflicts > 0:
+		fmt.Printf("\n⚠ Module '%s' updated with %d conflict(s); resolve the <<<<<<< markers before building.\n", moduleName, conflicts)
+	default:
+		fmt.Printf("\n✓ Module '%s' updated.\n", moduleName)
+	}
+}
+
+// removal is a planned edit taking a module's entries out of a shared file.
+type removal struct {
+	path, what    string
+	before, after string
+}
+
+// planRemoval works out what remove leaves of the file at path, reading it
+// from pending when an earlier removal already edited it, and records the
+// result there. It returns nil when there is nothing to remove.
+func planRemoval(pending map[string]string, path, what string, remove func(string) (string, bool, error)) *removal {
+	fullPath := resolvePath(projectRoot, path)
+	before, ok := pending[fullPath]
+	if !ok {
+		data, err := os.ReadFile(fullPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			fmt.Printf("  ✗ Error reading %s: %v\n", path, err)
+			return nil
+		}
+		before = string(data)
+	}
+	after, found, err := remove(before)
+	switch {
+	case err != nil:
+		fmt.Printf("  ✗ Error updating %s: %v\n", path, err)
+		return nil
+	case !found:
+		if verbose {
+			fmt.Printf("  • Not found in %s: %s\n", path, what)
+		}
+		return nil
+	}
+	pending[fullPath] = after
+	return &removal{path: path, what: what, before: before, after: after}
+}
+
+// generatedFields reads a module's fields back from its generated model
+// file, so its files can be rendered again and compared. It returns nil
+// when there is no model to read.
+func generatedFields(instructions []types.FileInstruction, moduleName string) []types.Field {
+	ctx := buildTemplateContext(moduleName, nil)
+	for _, instruction := range instructions {
+		filePath, err := renderTemplate(instruction.FilePath, instruction.FilePath, ctx)
+		if err != nil || !strings.HasSuffix(filePath, ".model.ts") {
+			continue
+		}
+		data, err := os.ReadFile(resolvePath(projectRoot, filePath))
+		if err != nil {
+			return nil
+		}
+		modelFields, _, err := fields.FromMongoose(string(data))
+		if err != nil {
+			return nil
+		}
+		return modelFields
+	}
+	return nil
+}
+
 func handleBulkUpload(filepath string) {
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
 	data, err := os.ReadFile(filepath)
 	if err != nil {
 		fmt.Printf("Error reading file: %v\n", err)
 		return
 	}
 
-	var modules []types.Module
-	if err := json.Unmarshal(data, &modules); err != nil {
-		fmt.Printf("Error parsing JSON: %v\n", err)
+	modules, err := parseModules(filepath, data)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	uploadModules(modules)
+}
+
+// handleOpenAPIUpload creates a module for every object schema of an
+// OpenAPI document.
+func handleOpenAPIUpload(path string) {
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error reading file: %v\n", err)
+		return
+	}
+
+	modules, warnings, err := openapi.Read(data)
+	for _, warning := range warnings {
+		fmt.Printf("  ⚠ %s\n", warning)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+	}
+	uploadModules(modules)
+}
+
+// uploadModules checks every module and, when all are valid, generates
+// them as backend modules.
+func uploadModules(modules []types.Module) {
+	// Check every module before writing anything
+	moduleFieldLists := make([][]types.Field, len(modules))
+	invalid := false
+	for i, module := range modules {
+		fields, err := moduleFields(module)
+		if err == nil && slices.ContainsFunc(modules[:i], func(m types.Module) bool { return strings.EqualFold(m.ModuleName, module.ModuleName) }) {
+			err = fmt.Errorf("module %q is defined more than once", module.ModuleName)
+		}
+		if err != nil {
+			fmt.Printf("[%d/%d] %s: ", i+1, len(modules), module.ModuleName)
+			printFieldError(err)
+			invalid = true
+			continue
+		}
+		moduleFieldLists[i] = fields
+	}
+	if invalid {
+		fmt.Println("\nNo files were written.")
 		return
 	}
 
 	if dryRun {
-		fmt.Println("\n🔍 DRY RUN MODE - No files will be created\n")
+		fmt.Print("\n🔍 DRY RUN MODE - No files will be created\n\n")
 	}
 
 	fmt.Printf("Creating %d modules...\n\n", len(modules))
 	for i, module := range modules {
 		fmt.Printf("[%d/%d] Creating module: %s\n", i+1, len(modules), module.ModuleName)
-		generateModule("bm", module)
+		if !generateModule("bm", module.ModuleName, moduleFieldLists[i]) {
+			fmt.Printf("\nStopped at %s; the modules before it were created.\n", module.ModuleName)
+			return
+		}
 		fmt.Println()
 	}
 	
@@ -177,259 +836,773 @@ func handleBulkUpload(filepath string) {
 	}
 }
 
+// parseModules reads the modules of an upload file, as YAML for .yaml and
+// .yml files and as JSON otherwise.
+func parseModules(path string, data []byte) ([]types.Module, error) {
+	var modules []types.Module
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &modules); err != nil {
+			return nil, fmt.Errorf("parsing YAML: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &modules); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %w", err)
+		}
+	}
+	return modules, nil
+}
+
 func handleInit(projectType string) {
 	fmt.Println("🚀 Initializing new project...")
 
-	if(projectType=="fp"){
-		 fmt.Println("Frontend project initialization is not supported yet!");
-		 return;
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	settings, err := collectInitSettings(projectType, stdin)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
 
-	instructions,updates:=backendmodule.GetInitProjectInstructions();
-	replacements:=map[string]string{
-			"PROJECT_NAME":"faltu",
+	instructions, updates, err := getInitInstructions(projectType)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		return
+	}
+
+	replacements := withDefaults(map[string]string{
+		"{{PROJECT_NAME}}":    settings.Project.Name,
+		"{{PORT}}":            settings.Project.Port,
+		"{{DATABASE_URL}}":    settings.Project.DatabaseURL,
+		"{{PACKAGE_MANAGER}}": settings.Project.PackageManager,
+	})
+	ctx := render.Context{
+		Project: settings.Project,
+		Legacy:  legacyTokens(replacements),
+	}
+
+	if verifyInit {
+		if !verifyProject(instructions, updates, ctx) {
+			os.Exit(1)
 		}
-	fmt.Println("📁 Creating files:")
-	for _, instruction := range instructions {
-		
-		filePath := applyReplacements(instruction.FilePath, replacements)
-		fileContent := applyReplacements(instruction.Content, replacements)
+		return
+	}
 
-		if verbose && instruction.Description != "" {
-			fmt.Printf("  ℹ %s\n", instruction.Description)
+	if dryRun {
+		fmt.Print("\n🔍 DRY RUN MODE - No files will be created\n\n")
+	} else if settings.Dir != "." {
+		if err := os.MkdirAll(settings.Dir, 0755); err != nil {
+			fmt.Printf("Error creating %s: %v\n", settings.Dir, err)
+			return
 		}
+	}
 
-		if dryRun {
-			fmt.Printf("  [DRY RUN] Would create: %s\n", filePath)
-		} else {
-			if err := createFile(filePath, fileContent); err != nil {
-				fmt.Printf("  ✗ Error creating %s: %v\n", filePath, err)
-				continue
-			}
-			fmt.Printf("  ✓ Created: %s\n", filePath)
+	entry := runInstructions(settings.Dir, instructions, updates, ctx)
+	if entry == nil {
+		return
+	}
+	entry.Name = settings.Project.Name
+	entry.Type = projectType
+	entry.Templates = manifest.Templates{Set: templateSet(projectType), Version: manifest.TemplateVersion(instructions, updates)}
+	recordGeneration(settings.Dir, entry)
+
+	fmt.Printf("\n✨ Project '%s' initialized! Next steps:\n", settings.Project.Name)
+	if settings.Dir != "." {
+		fmt.Printf("  cd %s\n", settings.Dir)
+	}
+	for _, step := range packageManagerSteps(settings.Project.PackageManager) {
+		fmt.Printf("  %s\n", step)
+	}
+	fmt.Println("Then use 'super create' to generate modules.")
+}
+
+// verifyProject renders the init templates in memory and checks that every
+// relative and @/ import in them resolves to a generated file.
+func verifyProject(instructions []types.FileInstruction, updates []types.UpdateInstruction, ctx render.Context) bool {
+	fmt.Println("🔎 Verifying generated imports...")
+	files, errs := renderInMemory(instructions, updates, ctx)
+	for _, err := range errs {
+		fmt.Printf("  ✗ %v\n", err)
+	}
+
+	problems, checked := verify.Imports(files, map[string]string{"@/": "src/"})
+	for _, problem := range problems {
+		fmt.Printf("  ✗ %s\n", problem)
+	}
+	if len(errs) > 0 || len(problems) > 0 {
+		fmt.Printf("\n✗ Verification failed: %d unresolved import(s), %d error(s)\n", len(problems), len(errs))
+		return false
+	}
+	fmt.Printf("  ✓ %d imports across %d files resolve\n", checked, len(files))
+	return true
+}
+
+type initSettings struct {
+	Project render.Project
+	// Dir is where the project is created, relative to the working
+	// directory.
+	Dir string
+}
+
+var (
+	projectNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	packageManagers    = []string{"npm", "yarn", "pnpm", "bun"}
+)
+
+// collectInitSettings gathers the init-time template variables from flags,
+// config defaults and, unless --yes is given, interactive prompts.
+func collectInitSettings(projectType string, reader *bufio.Reader) (initSettings, error) {
+	settings := initSettings{Dir: initName}
+	name := initName
+	if name == "" {
+		name = configDefault("PROJECT_NAME", "")
+		if !assumeYes || name == "" {
+			name = prompt(reader, "Enter project name", name)
+		}
+		settings.Dir = name
+	}
+	if name == "." {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return settings, err
+		}
+		name = filepath.Base(cwd)
+	}
+	if !projectNamePattern.MatchString(name) {
+		return settings, fmt.Errorf("invalid project name %q: use lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	if settings.Dir == "" {
+		return settings, fmt.Errorf("project name is required")
+	}
+	if !verifyInit {
+		if err := checkProjectDir(settings.Dir); err != nil {
+			return settings, err
 		}
 	}
 
-	// Update existing files
-	if len(updates) > 0 {
-		fmt.Println("\n🔧 Updating files:")
-		for _, update := range updates {
-			filePath := applyReplacements(update.FilePath, replacements)
-			placeholder := applyReplacements(update.Placeholder, replacements)
-			content := applyReplacements(update.Content, replacements)
+	defaultPort := "5000"
+	if projectType == "fp" {
+		defaultPort = "5173"
+	}
+	port := initPort
+	if port == "" {
+		port = promptOrDefault(reader, "Port", configDefault("PORT", defaultPort))
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		return settings, fmt.Errorf("invalid port %q", port)
+	}
 
-			if verbose && update.Description != "" {
-				fmt.Printf("  ℹ %s\n", update.Description)
-			}
+	databaseURL := initDatabaseURL
+	if databaseURL == "" && projectType == "bp" {
+		databaseURL = promptOrDefault(reader, "Database URL", configDefault("DATABASE_URL", "mongodb://localhost:27017/"+name))
+	}
+
+	packageManager := initPackageManager
+	if packageManager == "" {
+		packageManager = promptOrDefault(reader, "Package manager (npm, yarn, pnpm, bun)", configDefault("PACKAGE_MANAGER", "npm"))
+	}
+	if !slices.Contains(packageManagers, packageManager) {
+		return settings, fmt.Errorf("unsupported package manager %q (expected one of %s)", packageManager, strings.Join(packageManagers, ", "))
+	}
+
+	settings.Project = projectSettings(name)
+	settings.Project.Port = port
+	settings.Project.DatabaseURL = databaseURL
+	settings.Project.PackageManager = packageManager
+	return settings, nil
+}
+
+// checkProjectDir refuses to scaffold into a directory that already has
+// files in it, unless it is the working directory the user asked for with
+// ".".
+func checkProjectDir(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("directory %s already exists and is not empty", dir)
+	}
+	return nil
+}
+
+func configDefault(key, fallback string) string {
+	if currentConfig != nil {
+		if value, ok := currentConfig.Defaults[key]; ok && value != "" {
+			return value
+		}
+	}
+	return fallback
+}
+
+func prompt(reader *bufio.Reader, label, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s (%s): ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue
+	}
+	return answer
+}
+
+func promptOrDefault(reader *bufio.Reader, label, defaultValue string) string {
+	if assumeYes {
+		return defaultValue
+	}
+	return prompt(reader, label, defaultValue)
+}
+
+func packageManagerSteps(packageManager string) []string {
+	switch packageManager {
+	case "yarn":
+		return []string{"yarn", "yarn dev"}
+	case "pnpm":
+		return []string{"pnpm install", "pnpm dev"}
+	case "bun":
+		return []string{"bun install", "bun run dev"}
+	default:
+		return []string{"npm install", "npm run dev"}
+	}
+}
+
+func handleLoadConfig(filepath string) {
+	loaded, err := config.Load(filepath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Loaded configuration: %s (v%s)\n", loaded.Name, loaded.Version)
+	fmt.Printf("  - %d file instructions\n", len(loaded.FileInstructions))
+	fmt.Printf("  - %d update instructions\n", len(loaded.UpdateInstructions))
+	fmt.Printf("  - %d init file instructions\n", len(loaded.InitFileInstructions))
+	fmt.Printf("  - %d init update instructions\n", len(loaded.InitUpdateInstructions))
+}
 
+func handleExportConfig(filepath string) {
+	defaults, err := getDefaultConfig(false)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		return
+	}
+	configJSON, err := json.MarshalIndent(defaults, "", "  ")
+	if err != nil {
+		fmt.Printf("Error creating config: %v\n", err)
+		return
+	}
+
+	if err := os.WriteFile(filepath, configJSON, 0644); err != nil {
+		fmt.Printf("Error writing config file: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Configuration exported to: %s\n", filepath)
+}
+
+func handleValidateConfig(filepath string) {
+	loaded, err := config.Load(filepath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+
+	tokens := knownTokens()
+	for key := range loaded.Defaults {
+		tokens = append(tokens, key)
+	}
+	issues := config.Validate(loaded, tokens)
+	if len(issues) == 0 {
+		fmt.Printf("✓ %s is valid\n", filepath)
+		return
+	}
+
+	fmt.Printf("✗ Found %d issue(s) in %s:\n", len(issues), filepath)
+	for _, issue := range issues {
+		fmt.Printf("  - %s\n", issue)
+	}
+	os.Exit(1)
+}
+
+func handleShowConfig() {
+	if err := loadCurrentConfig(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	source := "built-in defaults"
+	if projectRoot != "" {
+		source = "built-in defaults + " + filepath.Join(projectRoot, config.DirName, "templates")
+	}
+	var effective types.ProjectConfig
+	if currentConfig != nil {
+		source = configSource
+		effective = *currentConfig
+	} else {
+		defaults, err := getDefaultConfig(true)
+		if err != nil {
+			fmt.Printf("Error loading templates: %v\n", err)
+			return
+		}
+		effective = defaults
+	}
+
+	configJSON, err := json.MarshalIndent(effective, "", "  ")
+	if err != nil {
+		fmt.Printf("Error creating config: %v\n", err)
+		return
+	}
+	fmt.Printf("# Source: %s\n", source)
+	fmt.Println(string(configJSON))
+}
+
+// generateModule writes a module and reports whether it was generated.
+func generateModule(moduleType, moduleName string, moduleFields []types.Field) bool {
+	ctx := buildTemplateContext(moduleName, moduleFields)
+
+	instructions, updates, err := getInstructions(moduleType)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		return false
+	}
+	entry := runInstructions(projectRoot, instructions, updates, ctx)
+	if entry == nil {
+		return false
+	}
+	if moduleType == "bm" {
+		updateOpenAPI(projectRoot, ctx)
+	}
+
+	entry.Name = moduleName
+	entry.Type = moduleType
+	entry.Fields = fields.Definitions(moduleFields)
+	entry.Templates = manifest.Templates{Set: templateSet(moduleType), Version: manifest.TemplateVersion(instructions, updates)}
+	recordGeneration(projectRoot, entry)
+	return true
+}
+
+// recordGeneration saves entry in the manifest of the project at root:
+// as the project itself for bp and fp, and as a module otherwise.
+func recordGeneration(root string, entry *manifest.Entry) {
+	if dryRun {
+		return
+	}
+	m, err := manifest.Load(root)
+	if err != nil {
+		fmt.Printf("  ✗ Error updating %s: %v\n", manifest.FileName, err)
+		return
+	}
+	entry.GeneratedAt = time.Now().UTC().Truncate(time.Second)
+	if entry.Type == "bp" || entry.Type == "fp" {
+		m.Project = entry
+	} else {
+		m.SetModule(entry)
+	}
+	if err := m.Save(root); err != nil {
+		fmt.Printf("  ✗ Error updating %s: %v\n", manifest.FileName, err)
+		return
+	}
+	if verbose {
+		fmt.Printf("  ✓ Recorded in %s\n", filepath.Join(config.DirName, manifest.FileName))
+	}
+}
+
+// templateSet names the templates getInstructions or getInitInstructions
+// use for kind: the project config's when it has instructions of that kind,
+// and the embedded set otherwise.
+func templateSet(kind string) string {
+	if currentConfig == nil {
+		return kind
+	}
+	switch {
+	case kind == "bm" && len(currentConfig.FileInstructions)+len(currentConfig.UpdateInstructions) > 0,
+		kind == "bp" && len(currentConfig.InitFileInstructions)+len(currentConfig.InitUpdateInstructions) > 0:
+		return currentConfig.Name
+	}
+	return kind
+}
+
+// updateOpenAPI writes the module's routes and schemas into the project's
+// openapi.json, creating the file when needed.
+func updateOpenAPI(baseDir string, ctx render.Context) {
+	path := resolvePath(baseDir, openapi.FileName)
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("  ✗ Error reading %s: %v\n", openapi.FileName, err)
+		return
+	}
+	spec, err := openapi.Merge(existing, ctx)
+	if err != nil {
+		fmt.Printf("  ✗ Error updating %s: %v\n", openapi.FileName, err)
+		return
+	}
+	if bytes.Equal(spec, existing) {
+		fmt.Printf("  • Up to date: %s\n", openapi.FileName)
+		return
+	}
+	if dryRun {
+		fmt.Printf("  [DRY RUN] Would update: %s (paths under /%s)\n", openapi.FileName, ctx.LowerCaseModuleName)
+		printDiff(openapi.FileName, string(existing), string(spec), existing == nil)
+		return
+	}
+	if err := os.WriteFile(path, spec, 0644); err != nil {
+		fmt.Printf("  ✗ Error updating %s: %v\n", openapi.FileName, err)
+		return
+	}
+	fmt.Printf("  ✓ Updated: %s\n", openapi.FileName)
+}
+
+// runInstructions creates and updates the files described by instructions.
+// Relative target paths are resolved against baseDir when it is set. Files
+// that already exist with other content are handled by the --on-conflict
+// policy. The returned entry lists what was written, for the manifest; it
+// is nil when a conflict stopped the run before anything was written.
+func runInstructions(baseDir string, instructions []types.FileInstruction, updates []types.UpdateInstruction, ctx render.Context) *manifest.Entry {
+	entry := &manifest.Entry{Files: []manifest.File{}}
+
+	// Settle every existing file before writing, so a conflict stops the
+	// run cleanly
+	type plannedFile struct {
+		path, content, description, action string
+	}
+	var planned []plannedFile
+	var conflicts []plannedFile
+	for _, instruction := range instructions {
+		filePath, err := renderTemplate(instruction.FilePath, instruction.FilePath, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering path %s: %v\n", instruction.FilePath, err)
+			continue
+		}
+		fileContent, err := renderTemplate(filePath, instruction.Content, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering %s: %v\n", filePath, err)
+			continue
+		}
+		action, err := fileAction(resolvePath(baseDir, filePath), filePath, fileContent)
+		if err != nil {
+			fmt.Printf("  ✗ Error reading %s: %v\n", filePath, err)
+			continue
+		}
+		switch action {
+		case "abort":
+			fmt.Println("Cancelled. Nothing was written.")
+			return nil
+		}
+		file := plannedFile{filePath, fileContent, instruction.Description, action}
+		if action == "fail" {
+			conflicts = append(conflicts, file)
+		}
+		planned = append(planned, file)
+	}
+	if len(conflicts) > 0 {
+		fmt.Println("✗ These files already exist and differ from the generated ones:")
+		for _, file := range conflicts {
+			fmt.Printf("  • %s\n", file.path)
+			// A dry run is how users inspect conflicts, so show each one
 			if dryRun {
-				fmt.Printf("  [DRY RUN] Would update: %s (at placeholder: %s)\n", filePath, placeholder)
-			} else {
-				if err := updateFile(filePath, placeholder, content, update.Position, update.CreateIfNotExists); err != nil {
-					fmt.Printf("  ✗ Error updating %s: %v\n", filePath, err)
-					continue
-				}
-				fmt.Printf("  ✓ Updated: %s\n", filePath)
+				existing, _ := os.ReadFile(resolvePath(baseDir, file.path))
+				printDiff(file.path, string(existing), file.content, false)
 			}
 		}
+		fmt.Println("Nothing was written. Choose what to do with --on-conflict=skip|overwrite|prompt|backup.")
+		return nil
 	}
 
+	// Create files. A dry run keeps what it would write in pending, so the
+	// previews of later updates to those files build on it.
+	pending := map[string]string{}
+	fmt.Println("📁 Creating files:")
+	for _, file := range planned {
+		if verbose && file.description != "" {
+			fmt.Printf("  ℹ %s\n", file.description)
+		}
 
-// 	baseStructure := []string{
-// 		"src/modules",
-// 		"src/features",
-// 		"src/config",
-// 		"src/utils",
-// 		"src/types",
-// 		"src/middlewares",
-// 	}
+		switch file.action {
+		case "skip":
+			fmt.Printf("  • Kept existing: %s\n", file.path)
+			continue
+		case "unchanged":
+			fmt.Printf("  • Unchanged: %s\n", file.path)
+			entry.Files = append(entry.Files, manifest.File{Path: file.path, Hash: manifest.Hash(file.content)})
+			continue
+		}
 
-// 	for _, dir := range baseStructure {
-// 		if dryRun {
-// 			fmt.Printf("  [DRY RUN] Would create: %s/\n", dir)
-// 		} else {
-// 			if err := os.MkdirAll(dir, 0755); err != nil {
-// 				fmt.Printf("  ✗ Error creating %s: %v\n", dir, err)
-// 				continue
-// 			}
-// 			fmt.Printf("  ✓ Created: %s/\n", dir)
-// 		}
-// 	}
+		path := resolvePath(baseDir, file.path)
+		if dryRun {
+			switch file.action {
+			case "overwrite":
+				fmt.Printf("  [DRY RUN] Would overwrite: %s\n", file.path)
+			case "backup":
+				fmt.Printf("  [DRY RUN] Would back up and overwrite: %s\n", file.path)
+			case "prompt":
+				fmt.Printf("  [DRY RUN] Would ask before overwriting: %s\n", file.path)
+			default:
+				fmt.Printf("  [DRY RUN] Would create: %s\n", file.path)
+			}
+			existing, _ := os.ReadFile(path)
+			printDiff(file.path, string(existing), file.content, file.action == "create")
+			pending[path] = file.content
+			continue
+		}
 
-// 	// Create sample config file
-// 	sampleConfig := getDefaultConfig()
-// 	configJSON, _ := json.MarshalIndent(sampleConfig, "", "  ")
-	
-// 	if dryRun {
-// 		fmt.Println("\n  [DRY RUN] Would create: super.config.json")
-// 	} else {
-// 		if err := os.WriteFile("super.config.json", configJSON, 0644); err != nil {
-// 			fmt.Printf("  ✗ Error creating config: %v\n", err)
-// 		} else {
-// 			fmt.Println("\n  ✓ Created: super.config.json")
-// 		}
-// 	}
+		note := ""
+		if file.action == "backup" {
+			backup, err := backupFile(path)
+			if err != nil {
+				fmt.Printf("  ✗ Error backing up %s: %v\n", file.path, err)
+				continue
+			}
+			note = fmt.Sprintf(" (previous version kept as %s)", filepath.Base(backup))
+		}
+		if err := createFile(path, file.content); err != nil {
+			fmt.Printf("  ✗ Error creating %s: %v\n", file.path, err)
+			continue
+		}
+		if file.action == "create" {
+			fmt.Printf("  ✓ Created: %s\n", file.path)
+		} else {
+			fmt.Printf("  ✓ Overwrote: %s%s\n", file.path, note)
+		}
+		entry.Files = append(entry.Files, manifest.File{Path: file.path, Hash: manifest.Hash(file.content)})
+	}
 
-// 	// Create main route file with placeholders
-// 	mainRouteContent := `import { Router } from 'express';
+	entry.Updates = runUpdates(baseDir, updates, ctx, pending)
+	return entry
+}
 
-// const router = Router();
+// runUpdates puts the snippets of updates into their files and returns the
+// insertions it made. A dry run prints the change each would make instead,
+// reading files from pending first.
+func runUpdates(baseDir string, updates []types.UpdateInstruction, ctx render.Context, pending map[string]string) []manifest.Update {
+	if len(updates) == 0 {
+		return nil
+	}
+	if pending == nil {
+		pending = map[string]string{}
+	}
+	var inserted []manifest.Update
+	fmt.Println("\n🔧 Updating files:")
+	for _, update := range updates {
+		filePath, err := renderTemplate(update.FilePath, update.FilePath, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering path %s: %v\n", update.FilePath, err)
+			continue
+		}
+		placeholder, err := renderTemplate(filePath+" placeholder", update.Placeholder, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering placeholder for %s: %v\n", filePath, err)
+			continue
+		}
+		content, err := renderTemplate(filePath, update.Content, ctx)
+		if err != nil {
+			fmt.Printf("  ✗ Error rendering update for %s: %v\n", filePath, err)
+			continue
+		}
 
-// // SUPER_CLI_ROUTE_IMPORTS
-// // Auto-generated route imports will be added here
+		if verbose && update.Description != "" {
+			fmt.Printf("  ℹ %s\n", update.Description)
+		}
 
-// // SUPER_CLI_ROUTE_DEFINITIONS
-// // Auto-generated route definitions will be added here
+		block := blockID(update, ctx)
+		if dryRun {
+			fmt.Printf("  [DRY RUN] Would update: %s (at placeholder: %s)\n", filePath, placeholder)
+			previewUpdate(resolvePath(baseDir, filePath), filePath, placeholder, block, content, update, pending)
+		} else {
+			changed, err := updateFile(resolvePath(baseDir, filePath), placeholder, block, content, update)
+			if err != nil {
+				fmt.Printf("  ✗ Error updating %s: %v\n", filePath, err)
+				continue
+			}
+			if changed {
+				fmt.Printf("  ✓ Updated: %s\n", filePath)
+			} else {
+				fmt.Printf("  • Up to date: %s (block %s)\n", filePath, block)
+			}
+			// The block is in the file either way, so the record keeps it
+			inserted = append(inserted, manifest.Update{Path: filePath, Block: block, Hash: manifest.Hash(content)})
+		}
+	}
+	return inserted
+}
 
-// export default router;
-// `
-
-// 	if dryRun {
-// 		fmt.Println("  [DRY RUN] Would create: src/routes/index.ts")
-// 	} else {
-// 		if err := createFile("src/routes/index.ts", mainRouteContent); err != nil {
-// 			fmt.Printf("  ✗ Error creating routes file: %v\n", err)
-// 		} else {
-// 			fmt.Println("  ✓ Created: src/routes/index.ts")
-// 		}
-// 	}
+func resolvePath(baseDir, path string) string {
+	if baseDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
 
-	fmt.Println("\n✨ Project initialized! You can now use 'super create' to generate modules.")
+func parseFields(fieldsStr string) ([]types.Field, error) {
+	return fields.Parse(fieldsStr, customTypes()...)
 }
 
-// func handleLoadConfig(filepath string) {
-// 	data, err := os.ReadFile(filepath)
-// 	if err != nil {
-// 		fmt.Printf("Error reading config file: %v\n", err)
-// 		return
-// 	}
+// readFieldsFrom reads the fields of an existing Mongoose model or
+// TypeScript interface file and lists them, with anything it had to skip.
+func readFieldsFrom(path string) ([]types.Field, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sourceFields, warnings, err := fields.FromSource(string(data))
+	for _, warning := range warnings {
+		fmt.Printf("  ⚠ %s\n", warning)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := fields.Check(sourceFields, customTypes()...); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
-// 	var config types.ProjectConfig
-// 	if err := json.Unmarshal(data, &config); err != nil {
-// 		fmt.Printf("Error parsing config: %v\n", err)
-// 		return
-// 	}
+	fmt.Printf("Fields read from %s:\n", path)
+	printFields(sourceFields)
+	return sourceFields, nil
+}
 
-// 	currentConfig = &config
-// 	fmt.Printf("✓ Loaded configuration: %s (v%s)\n", config.Name, config.Version)
-// 	fmt.Printf("  - %d file instructions\n", len(config.FileInstructions))
-// 	fmt.Printf("  - %d update instructions\n", len(config.UpdateInstructions))
-// }
+// readFieldsFromSample infers fields from an example JSON payload and lists
+// them, with anything it had to skip.
+func readFieldsFromSample(path string) ([]types.Field, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sampleFields, warnings, err := fields.FromSample(data)
+	for _, warning := range warnings {
+		fmt.Printf("  ⚠ %s\n", warning)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := fields.Check(sampleFields, customTypes()...); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
-// func handleExportConfig(filepath string) {
-// 	config := getDefaultConfig()
-// 	configJSON, err := json.MarshalIndent(config, "", "  ")
-// 	if err != nil {
-// 		fmt.Printf("Error creating config: %v\n", err)
-// 		return
-// 	}
+	fmt.Printf("Fields inferred from %s:\n", path)
+	printFields(sampleFields)
+	return sampleFields, nil
+}
 
-// 	if err := os.WriteFile(filepath, configJSON, 0644); err != nil {
-// 		fmt.Printf("Error writing config file: %v\n", err)
-// 		return
-// 	}
+func printFields(list []types.Field) {
+	for _, f := range list {
+		fmt.Printf("  • %s\n", fields.Format([]types.Field{f}))
+	}
+	fmt.Println()
+}
 
-// 	fmt.Printf("✓ Configuration exported to: %s\n", filepath)
-// }
+// customTypes returns the field type codes the project config maps on top
+// of the built-in ones.
+func customTypes() []string {
+	if currentConfig == nil {
+		return nil
+	}
+	codes := make([]string, 0, len(currentConfig.TypeMappings))
+	for code := range currentConfig.TypeMappings {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
 
-func generateModule(moduleType string, module types.Module) {
-	fields := parseFields(module.ModelProperties)
-	replacements := buildReplacements(module.ModuleName, fields)
-
-	// // Load custom config if specified
-	// var config *ProjectConfig
-	// if configFile != "" {
-	// 	handleLoadConfig(configFile)
-	// 	config = currentConfig
-	// }
-
-	// Get instructions
-	// var instructions []types.FileInstruction
-	// var updates []types.UpdateInstruction
-
-	// if config != nil {
-	// 	instructions = config.FileInstructions
-	// 	updates = config.UpdateInstructions
-	// } else {
-		
-	// }
-	instructions,updates := getInstructions(moduleType)
-
-	// Create files
-	fmt.Println("📁 Creating files:")
-	for _, instruction := range instructions {
-		filePath := applyReplacements(instruction.FilePath, replacements)
-		fileContent := applyReplacements(instruction.Content, replacements)
+var moduleNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
 
-		if verbose && instruction.Description != "" {
-			fmt.Printf("  ℹ %s\n", instruction.Description)
-		}
+// moduleFields checks a module's name and returns its fields, from its
+// structured definitions or its field string, whichever is set.
+func moduleFields(module types.Module) ([]types.Field, error) {
+	if !moduleNamePattern.MatchString(module.ModuleName) {
+		return nil, fmt.Errorf("invalid module name %q: use letters, digits and _, starting with a letter", module.ModuleName)
+	}
+	if len(module.Fields) == 0 {
+		return parseFields(module.ModelProperties)
+	}
+	if strings.TrimSpace(module.ModelProperties) != "" {
+		return nil, fmt.Errorf("module %q sets both fields and modelProperties", module.ModuleName)
+	}
+	return fields.FromDefinitions(module.Fields, customTypes()...)
+}
 
-		if dryRun {
-			fmt.Printf("  [DRY RUN] Would create: %s\n", filePath)
-		} else {
-			if err := createFile(filePath, fileContent); err != nil {
-				fmt.Printf("  ✗ Error creating %s: %v\n", filePath, err)
-				continue
-			}
-			fmt.Printf("  ✓ Created: %s\n", filePath)
+// printFieldError prints err, with the offending input marked when it is a
+// syntax error in a field string.
+func printFieldError(err error) {
+	fmt.Printf("Error: %v\n", err)
+	var syntaxErr *fields.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		for _, line := range strings.Split(syntaxErr.Snippet(), "\n") {
+			fmt.Printf("  %s\n", line)
 		}
 	}
+}
 
-	// Update existing files
-	if len(updates) > 0 {
-		fmt.Println("\n🔧 Updating files:")
-		for _, update := range updates {
-			filePath := applyReplacements(update.FilePath, replacements)
-			placeholder := applyReplacements(update.Placeholder, replacements)
-			content := applyReplacements(update.Content, replacements)
-
-			if verbose && update.Description != "" {
-				fmt.Printf("  ℹ %s\n", update.Description)
-			}
-
-			if dryRun {
-				fmt.Printf("  [DRY RUN] Would update: %s (at placeholder: %s)\n", filePath, placeholder)
-			} else {
-				if err := updateFile(filePath, placeholder, content, update.Position, update.CreateIfNotExists); err != nil {
-					fmt.Printf("  ✗ Error updating %s: %v\n", filePath, err)
-					continue
-				}
-				fmt.Printf("  ✓ Updated: %s\n", filePath)
-			}
-		}
+func getInitInstructions(projectType string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
+	if projectType == "fp" {
+		return frontendmodule.GetInitProjectInstructions(templateOverrideDir("fp"))
 	}
+	if currentConfig != nil && len(currentConfig.InitFileInstructions)+len(currentConfig.InitUpdateInstructions) > 0 {
+		return currentConfig.InitFileInstructions, currentConfig.InitUpdateInstructions, nil
+	}
+	return backendmodule.GetInitProjectInstructions(templateOverrideDir("bp"))
 }
 
-func parseFields(fieldsStr string) []types.Field {
-	if fieldsStr == "" {
-		return []types.Field{}
+// templateOverrideDir returns the project's .super/templates/<set> directory,
+// whose files take priority over the embedded templates, or "" outside a
+// project.
+func templateOverrideDir(set string) string {
+	if projectRoot == "" {
+		return ""
 	}
+	return filepath.Join(projectRoot, config.DirName, "templates", set)
+}
 
-	fieldParts := strings.Split(fieldsStr, ",")
-	fields := make([]types.Field, 0, len(fieldParts))
+// loadCurrentConfig loads the config in effect into currentConfig: the file
+// passed with --config, or else the super.config.json / .super/config.json
+// discovered above the working directory. It also records the discovered
+// project root, which module files are generated relative to.
+func loadCurrentConfig() error {
+	root, discovered, err := config.Discover(".")
+	if err != nil {
+		return err
+	}
+	projectRoot = root
 
-	for _, part := range fieldParts {
-		segments := strings.Split(strings.TrimSpace(part), "@")
-		if len(segments) >= 2 {
-			field := types.Field{
-				Name:     segments[0],
-				Type:     segments[1],
-				Required: len(segments) >= 3 && segments[2] == "R",
-			}
-			fields = append(fields, field)
-		}
+	path := configFile
+	if path == "" {
+		path = discovered
+	}
+	if path == "" {
+		return nil
 	}
 
-	return fields
+	loaded, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	currentConfig = loaded
+	configSource = path
+	if verbose {
+		fmt.Printf("ℹ Using config: %s (v%s) from %s\n", loaded.Name, loaded.Version, path)
+	}
+	return nil
 }
 
-func getInstructions(moduleType string) ([]types.FileInstruction,[]types.UpdateInstruction) {
+func getInstructions(moduleType string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
 	if moduleType == "bm" {
-		return backendmodule.GetCreateBackendModuleInstructions()
+		if currentConfig != nil && len(currentConfig.FileInstructions)+len(currentConfig.UpdateInstructions) > 0 {
+			return currentConfig.FileInstructions, currentConfig.UpdateInstructions, nil
+		}
+		return backendmodule.GetCreateBackendModuleInstructions(templateOverrideDir("bm"))
 	}
-	return []types.FileInstruction{},[]types.UpdateInstruction{}
-	// return getFrontendInstructions()
+	return frontendmodule.GetCreateFrontendModuleInstructions(templateOverrideDir("fm"))
 }
 
 // func getBackendInstructions() []FileInstruction {
@@ -588,182 +1761,341 @@ func getInstructions(moduleType string) ([]types.FileInstruction,[]types.UpdateI
 // 	}
 // }
 
-// func getFrontendInstructions() []FileInstruction {
-// 	return []FileInstruction{
-// 		{
-// 			FilePath:    "src/features/{{LOWER_CASE_MODULE_NAME}}/components/{{PASCAL_CASE_MODULE_NAME}}List.tsx",
-// 			Description: "Creating React list component",
-// 			Content: `import React from 'react';
-// import { use{{PASCAL_CASE_MODULE_NAME}}s } from '../hooks/use{{PASCAL_CASE_MODULE_NAME}}s';
-
-// export const {{PASCAL_CASE_MODULE_NAME}}List: React.FC = () => {
-//   const { data, isLoading, error } = use{{PASCAL_CASE_MODULE_NAME}}s();
-
-//   if (isLoading) return <div>Loading...</div>;
-//   if (error) return <div>Error: {error.message}</div>;
-
-//   return (
-//     <div>
-//       <h2>{{PASCAL_CASE_MODULE_NAME}}s</h2>
-//       <ul>
-//         {data?.map(item => (
-//           <li key={item.id}>{JSON.stringify(item)}</li>
-//         ))}
-//       </ul>
-//     </div>
-//   );
-// };
-// `,
-// 		},
-// 		{
-// 			FilePath:    "src/features/{{LOWER_CASE_MODULE_NAME}}/hooks/use{{PASCAL_CASE_MODULE_NAME}}s.ts",
-// 			Description: "Creating React Query hook",
-// 			Content: `import { useQuery } from '@tanstack/react-query';
-// import { {{LOWER_CASE_MODULE_NAME}}Api } from '../api/{{LOWER_CASE_MODULE_NAME}}.api';
-
-// export const use{{PASCAL_CASE_MODULE_NAME}}s = () => {
-//   return useQuery({
-//     queryKey: ['{{LOWER_CASE_MODULE_NAME}}s'],
-//     queryFn: {{LOWER_CASE_MODULE_NAME}}Api.getAll,
-//   });
-// };
-// `,
-// 		},
-// 		{
-// 			FilePath:    "src/features/{{LOWER_CASE_MODULE_NAME}}/api/{{LOWER_CASE_MODULE_NAME}}.api.ts",
-// 			Description: "Creating API service",
-// 			Content: `import axios from 'axios';
-
-// const BASE_URL = '/api/{{LOWER_CASE_MODULE_NAME}}s';
-
-// export const {{LOWER_CASE_MODULE_NAME}}Api = {
-//   getAll: async () => {
-//     const response = await axios.get(BASE_URL);
-//     return response.data;
-//   },
-
-//   getOne: async (id: string) => {
-//     const response = await axios.get(\${BASE_URL}/\${id});
-//     return response.data;
-//   },
-
-//   create: async (data: any) => {
-//     const response = await axios.post(BASE_URL, data);
-//     return response.data;
-//   },
-
-//   update: async (id: string, data: any) => {
-//     const response = await axios.put(\${BASE_URL}/\${id}\, data);
-//     return response.data;
-//   },
-
-//   delete: async (id: string) => {
-//     await axios.delete(\${BASE_URL}/\${id});
-//   },
-// };
-// `,
-// 		},
-// 	}
-// }
-
-// func getUpdateInstructions(moduleType string) []UpdateInstruction {
-// 	if moduleType == "bm" {
-// 		return []UpdateInstruction{
-// 			{
-// 				FilePath:          "src/routes/index.ts",
-// 				Placeholder:       "// SUPER_CLI_ROUTE_IMPORTS",
-// 				Content:           "import {{LOWER_CASE_MODULE_NAME}}Routes from '../modules/{{LOWER_CASE_MODULE_NAME}}/{{LOWER_CASE_MODULE_NAME}}.routes';",
-// 				Position:          "bottom",
-// 				CreateIfNotExists: true,
-// 				Description:       "Adding route import to main routes file",
-// 			},
-// 			{
-// 				FilePath:          "src/routes/index.ts",
-// 				Placeholder:       "// SUPER_CLI_ROUTE_DEFINITIONS",
-// 				Content:           "router.use('/api/{{LOWER_CASE_MODULE_NAME}}s', {{LOWER_CASE_MODULE_NAME}}Routes);",
-// 				Position:          "bottom",
-// 				CreateIfNotExists: false,
-// 				Description:       "Registering route in main router",
-// 			},
-// 		}
-// 	}
-// 	return []UpdateInstruction{}
-// }
-
-// func getDefaultConfig() ProjectConfig {
-// 	return ProjectConfig{
-// 		Name:               "super-cli-config",
-// 		Version:            "1.0.0",
-// 		FileInstructions:   backendmodule.GetCreateBackendModuleInstructions(),
-// 		UpdateInstructions: getUpdateInstructions("bm"),
-// 	}
-// }
+func getDefaultConfig(withOverrides bool) (types.ProjectConfig, error) {
+	var bmOverrides, bpOverrides string
+	if withOverrides {
+		bmOverrides = templateOverrideDir("bm")
+		bpOverrides = templateOverrideDir("bp")
+	}
+	fileInstructions, updateInstructions, err := backendmodule.GetCreateBackendModuleInstructions(bmOverrides)
+	if err != nil {
+		return types.ProjectConfig{}, err
+	}
+	initFileInstructions, initUpdateInstructions, err := backendmodule.GetInitProjectInstructions(bpOverrides)
+	if err != nil {
+		return types.ProjectConfig{}, err
+	}
+	return types.ProjectConfig{
+		Name:                   "super-cli-config",
+		Version:                config.Version,
+		FileInstructions:       fileInstructions,
+		UpdateInstructions:     updateInstructions,
+		InitFileInstructions:   initFileInstructions,
+		InitUpdateInstructions: initUpdateInstructions,
+	}, nil
+}
 
-func updateFile(filePath, placeholder, content, position string, createIfNotExists bool) error {
+// updateFile puts content into the marker block named block, replacing the
+// block if an earlier run inserted it. It reports whether the file changed.
+func updateFile(filePath, placeholder, block, content string, update types.UpdateInstruction) (bool, error) {
 	// Check if file exists
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
-		if createIfNotExists && os.IsNotExist(err) {
+		if update.CreateIfNotExists && os.IsNotExist(err) {
 			// Create file with placeholder and content
 			dir := filepath.Dir(filePath)
 			if err := os.MkdirAll(dir, 0755); err != nil {
-				return err
+				return false, err
 			}
-			newContent := fmt.Sprintf("%s\n%s\n", placeholder, content)
-			return os.WriteFile(filePath, []byte(newContent), 0644)
+			fileContent = []byte(placeholder + "\n")
+		} else {
+			return false, err
 		}
-		return err
 	}
 
-	fileStr := string(fileContent)
+	newContent, changed, err := applyUpdate(string(fileContent), placeholder, block, content, update)
+	if err != nil || !changed {
+		return false, err
+	}
+	return true, os.WriteFile(filePath, []byte(newContent), 0644)
+}
 
-	// Check if placeholder exists
-	if !strings.Contains(fileStr, placeholder) {
-		return fmt.Errorf("placeholder '%s' not found in file", placeholder)
+// applyUpdate puts content into file next to placeholder or, when the file
+// lacks it, at the update's structural target.
+func applyUpdate(file, placeholder, block, content string, update types.UpdateInstruction) (string, bool, error) {
+	updated, changed, err := markers.Apply(file, placeholder, block, content, update.Position)
+	var anchorErr *markers.AnchorError
+	if !errors.As(err, &anchorErr) || update.Target == "" {
+		return updated, changed, err
+	}
+	if update.Target == tsedit.ImportTarget && tsedit.HasImport(file, content) {
+		return file, false, nil
 	}
+	point, locateErr := tsedit.Locate(file, update.Target)
+	if locateErr != nil {
+		return "", false, fmt.Errorf("%v, and %w", err, locateErr)
+	}
+	return markers.Insert(point.Src, point.At, point.Indent, block, content)
+}
 
-	// Check if content already exists (avoid duplicates)
-	if strings.Contains(fileStr, content) {
-		if verbose {
-			fmt.Printf("    ⚠ Content already exists in %s, skipping\n", filePath)
-		}
-		return nil
+var blockNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// blockID names the marker block of an update for the module in ctx, such
+// as "route:order".
+func blockID(update types.UpdateInstruction, ctx render.Context) string {
+	name := update.Block
+	if name == "" {
+		name = strings.ToLower(strings.Trim(blockNameSanitizer.ReplaceAllString(update.Placeholder, "-"), "-"))
 	}
+	if ctx.LowerCaseModuleName == "" {
+		return name
+	}
+	return name + ":" + ctx.LowerCaseModuleName
+}
 
-	// Determine insertion position
-	var newContent string
-	if position == "bottom" {
-		// Add content after placeholder
-		newContent = strings.Replace(fileStr, placeholder, placeholder+"\n"+content, 1)
-	} else {
-		// Default: add content before placeholder (top)
-		newContent = strings.Replace(fileStr, placeholder, content+"\n"+placeholder, 1)
+// renderInMemory renders instructions into a map of path to content without
+// touching the disk, applying updates to the rendered files. It returns
+// every rendering or update error it hits.
+func renderInMemory(instructions []types.FileInstruction, updates []types.UpdateInstruction, ctx render.Context) (map[string]string, []error) {
+	files := map[string]string{}
+	var errs []error
+	for _, instruction := range instructions {
+		filePath, err := renderTemplate(instruction.FilePath, instruction.FilePath, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", instruction.FilePath, err))
+			continue
+		}
+		content, err := renderTemplate(filePath, instruction.Content, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			continue
+		}
+		files[filepath.ToSlash(filepath.Clean(filePath))] = content
 	}
 
-	return os.WriteFile(filePath, []byte(newContent), 0644)
+	for _, update := range updates {
+		filePath, err := renderTemplate(update.FilePath, update.FilePath, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", update.FilePath, err))
+			continue
+		}
+		placeholder, err := renderTemplate(filePath+" placeholder", update.Placeholder, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			continue
+		}
+		content, err := renderTemplate(filePath, update.Content, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			continue
+		}
+
+		key := filepath.ToSlash(filepath.Clean(filePath))
+		existing, ok := files[key]
+		if !ok {
+			if !update.CreateIfNotExists {
+				errs = append(errs, fmt.Errorf("%s: file is not generated, cannot update it", filePath))
+				continue
+			}
+			existing = placeholder + "\n"
+		}
+		updated, _, err := applyUpdate(existing, placeholder, blockID(update, ctx), content, update)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			continue
+		}
+		files[key] = updated
+	}
+	return files, errs
 }
 
 func buildReplacements(moduleName string, fields []types.Field) map[string]string {
-	return map[string]string{
+	return withDefaults(map[string]string{
 		"{{MODULE_NAME}}":                moduleName,
 		"{{LOWER_CASE_MODULE_NAME}}":     strings.ToLower(moduleName),
 		"{{UPPER_CASE_MODULE_NAME}}":     strings.ToUpper(moduleName),
 		"{{PASCAL_CASE_MODULE_NAME}}":    toPascalCase(moduleName),
 		"{{CAMEL_CASE_MODULE_NAME}}":     toCamelCase(moduleName),
-		"{{MODEL_FIELDS}}":               generateModelFields(fields),
-		"{{MONGOOSE_SCHEMA_FIELDS}}":     generateMongooseFields(fields),
+		"{{MODEL_FIELDS}}":               generateModelFields(moduleName, fields),
+		"{{MODEL_INTERFACES}}":           generateModelInterfaces(moduleName, fields),
+		"{{MONGOOSE_SCHEMA_FIELDS}}":     generateMongooseFields(moduleName, fields),
+		"{{MONGOOSE_SUB_SCHEMAS}}":       generateMongooseSubSchemas(moduleName, fields),
 		"{{ZOD_GENERATED_SCHEMA}}":       generateZodSchema(moduleName, fields),
 		"{{ZOD_INFER_TYPES}}":            generateZodTypes(moduleName),
 		"{{ZOD_EXPORTS}}":                generateZodExports(moduleName),
+	})
+}
+
+// withDefaults adds the config's default token values to replacements
+// without overriding tokens that are already set.
+func withDefaults(replacements map[string]string) map[string]string {
+	if currentConfig == nil {
+		return replacements
+	}
+	for key, value := range currentConfig.Defaults {
+		token := "{{" + key + "}}"
+		if _, ok := replacements[token]; !ok {
+			replacements[token] = value
+		}
+	}
+	return replacements
+}
+
+// knownTokens returns the {{TOKEN}} names the generator knows how to fill in,
+// without braces.
+func knownTokens() []string {
+	tokens := []string{"PROJECT_NAME", "PORT", "DATABASE_URL", "PACKAGE_MANAGER"}
+	for key := range buildReplacements("", nil) {
+		tokens = append(tokens, strings.Trim(key, "{}"))
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// buildTemplateContext returns the data module templates are rendered with.
+func buildTemplateContext(moduleName string, fields []types.Field) render.Context {
+	replacements := buildReplacements(moduleName, fields)
+	return render.Context{
+		ModuleName:           moduleName,
+		LowerCaseModuleName:  strings.ToLower(moduleName),
+		UpperCaseModuleName:  strings.ToUpper(moduleName),
+		PascalCaseModuleName: toPascalCase(moduleName),
+		CamelCaseModuleName:  toCamelCase(moduleName),
+		Fields:               fields,
+		Project:              projectSettings(replacements["{{PROJECT_NAME}}"]),
+		Legacy:               legacyTokens(replacements),
+	}
+}
+
+func projectSettings(name string) render.Project {
+	project := render.Project{Name: name, Vars: map[string]string{}}
+	if currentConfig != nil {
+		for key, value := range currentConfig.Defaults {
+			project.Vars[key] = value
+		}
 	}
+	return project
 }
 
-func applyReplacements(content string, replacements map[string]string) string {
-	result := content
+// legacyTokens strips the braces from replacement keys so they can be
+// exposed as template functions.
+func legacyTokens(replacements map[string]string) map[string]string {
+	tokens := make(map[string]string, len(replacements))
 	for key, value := range replacements {
-		result = strings.ReplaceAll(result, key, value)
+		tokens[strings.Trim(key, "{}")] = value
+	}
+	return tokens
+}
+
+// templateFuncs are the helpers available to every template on top of the
+// legacy {{TOKEN}} functions.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":           strings.ToLower,
+		"upper":           strings.ToUpper,
+		"pascal":          toPascalCase,
+		"camel":           toCamelCase,
+		"tsType":          mapTypeToTypeScript,
+		"mongooseType":    mapTypeToMongoose,
+		"zodType":         mapTypeToZod,
+		"label":           toLabel,
+		"inputType":       mapTypeToInputType,
+		"registerOptions": mapTypeToRegisterOptions,
+	}
+}
+
+func renderTemplate(name, text string, ctx render.Context) (string, error) {
+	return render.Render(name, text, ctx, templateFuncs())
+}
+
+// previewUpdate prints the change an update would make to the file at path
+// and keeps the result in pending for the updates after it.
+func previewUpdate(path, name, placeholder, block, content string, update types.UpdateInstruction, pending map[string]string) {
+	before, ok := pending[path]
+	start, created := before, false
+	if !ok {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			before, start = string(data), string(data)
+		case os.IsNotExist(err) && update.CreateIfNotExists:
+			start, created = placeholder+"\n", true
+		default:
+			fmt.Printf("  ✗ Error reading %s: %v\n", name, err)
+			return
+		}
+	}
+	after, changed, err := applyUpdate(start, placeholder, block, content, update)
+	if err != nil {
+		fmt.Printf("  ✗ Error updating %s: %v\n", name, err)
+		return
+	}
+	if !changed {
+		fmt.Printf("    • Block %s already up to date\n", block)
+		return
+	}
+	pending[path] = after
+	printDiff(name, before, after, created)
+}
+
+// printDiff prints the change to the file name as a unified diff, from
+// /dev/null when the file would be created. The diff is colored when
+// stdout is a terminal and NO_COLOR is not set.
+func printDiff(name, before, after string, created bool) {
+	from := "a/" + name
+	if created {
+		from = "/dev/null"
+	}
+	text := diff.Unified(before, after, from, "b/"+name)
+	if text == "" {
+		return
+	}
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 && os.Getenv("NO_COLOR") == "" {
+		text = diff.Colorize(text)
+	}
+	fmt.Print(text)
+}
+
+// fileAction decides what runInstructions does with the generated file at
+// path: "create" when it is new, "unchanged" when it already holds content,
+// and otherwise the --on-conflict policy. Under prompt, the user is shown a
+// diff and picks overwrite, skip, backup or abort.
+func fileAction(path, name, content string) (string, error) {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "create", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if string(existing) == content {
+		return "unchanged", nil
+	}
+
+	policy := onConflict
+	if policy == "" {
+		policy = "fail"
+	}
+	if policy != "prompt" || dryRun {
+		return policy, nil
+	}
+	fmt.Printf("\n⚠ %s already exists and differs from the generated file:\n", name)
+	fmt.Print(diff.Unified(string(existing), content, "a/"+name, "b/"+name))
+	for {
+		switch strings.ToLower(prompt(stdin, "[o]verwrite, [s]kip, [b]ackup and overwrite, or [a]bort", "s")) {
+		case "o", "overwrite":
+			return "overwrite", nil
+		case "s", "skip":
+			return "skip", nil
+		case "b", "backup":
+			return "backup", nil
+		case "a", "abort":
+			return "abort", nil
+		}
+	}
+}
+
+// backupFile renames path to path.bak, or to the first free path.bak.N,
+// and returns the new name.
+func backupFile(path string) (string, error) {
+	backup := path + ".bak"
+	for n := 1; ; n++ {
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			break
+		}
+		backup = fmt.Sprintf("%s.bak.%d", path, n)
 	}
-	return result
+	return backup, os.Rename(path, backup)
 }
 
 func createFile(path, content string) error {
@@ -788,49 +2120,273 @@ func toCamelCase(s string) string {
 	return strings.ToLower(string(s[0])) + s[1:]
 }
 
-func generateModelFields(fields []types.Field) string {
+// toLabel turns a camelCase field name into a human readable label, e.g.
+// "placedAt" becomes "Placed At".
+func toLabel(s string) string {
+	var result strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result.WriteByte(' ')
+		}
+		result.WriteRune(r)
+	}
+	return toPascalCase(result.String())
+}
+
+func generateModelFields(moduleName string, fields []types.Field) string {
 	if len(fields) == 0 {
 		return "  // Add your fields here"
 	}
+	return interfaceBody(toPascalCase(moduleName), fields)
+}
+
+func interfaceBody(prefix string, fields []types.Field) string {
 	var result strings.Builder
 	for _, f := range fields {
-		tsType := mapTypeToTypeScript(f.Type)
 		optional := ""
 		if !f.Required {
 			optional = "?"
 		}
-		result.WriteString(fmt.Sprintf("  %s%s: %s;\n", f.Name, optional, tsType))
+		if f.Description != "" {
+			// A "*/" in the text would end the comment early
+			result.WriteString(fmt.Sprintf("  /** %s */\n", strings.ReplaceAll(f.Description, "*/", "*\\/")))
+		}
+		result.WriteString(fmt.Sprintf("  %s%s: %s;\n", f.Name, optional, fieldTypeScript(prefix, f)))
 	}
 	return strings.TrimRight(result.String(), "\n")
 }
 
-func generateMongooseFields(fields []types.Field) string {
+// generateModelInterfaces declares an interface for every nested object
+// field, innermost first, e.g. IOrderAddress for order's address field.
+func generateModelInterfaces(moduleName string, fields []types.Field) string {
+	var result strings.Builder
+	walkObjects(toPascalCase(moduleName), fields, func(name string, f types.Field) {
+		result.WriteString(fmt.Sprintf("export interface I%s {\n%s\n}\n\n", name, interfaceBody(name, f.Fields)))
+	})
+	return strings.TrimRight(result.String(), "\n")
+}
+
+// walkObjects calls fn for every object field below fields, children before
+// their parents. name is the field's declaration name, prefix plus the
+// PascalCase field names leading to it.
+func walkObjects(prefix string, fields []types.Field, fn func(name string, f types.Field)) {
+	for _, f := range fields {
+		if f.Type != "O" {
+			continue
+		}
+		name := prefix + toPascalCase(f.Name)
+		walkObjects(name, f.Fields, fn)
+		fn(name, f)
+	}
+}
+
+// fieldTypeScript returns the TypeScript type of a field, including enum
+// unions, references, nested objects and lists.
+func fieldTypeScript(prefix string, f types.Field) string {
+	tsType := mapTypeToTypeScript(f.Type)
+	switch f.Type {
+	case "E":
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = strconv.Quote(value)
+		}
+		tsType = strings.Join(values, " | ")
+	case "REF":
+		tsType = "Types.ObjectId | string"
+	case "O":
+		tsType = "I" + prefix + toPascalCase(f.Name)
+	}
+	if !f.Array {
+		return tsType
+	}
+	if strings.Contains(tsType, " | ") {
+		return "(" + tsType + ")[]"
+	}
+	return tsType + "[]"
+}
+
+// generateMongooseFields lists the schema fields at the indentation of the
+// model template's schema definition block.
+func generateMongooseFields(moduleName string, fields []types.Field) string {
 	if len(fields) == 0 {
-		return "  // Add your fields here"
+		return "        // Add your fields here"
 	}
+	return mongooseFieldLines(toPascalCase(moduleName), fields, "        ")
+}
+
+func mongooseFieldLines(prefix string, fields []types.Field, indent string) string {
 	var result strings.Builder
 	for _, f := range fields {
-		mongoType := mapTypeToMongoose(f.Type)
-		result.WriteString(fmt.Sprintf("  %s: { type: %s, required: %t },\n", f.Name, mongoType, f.Required))
+		result.WriteString(fmt.Sprintf("%s%s: { %s },\n", indent, f.Name, strings.Join(mongooseFieldOptions(prefix, f), ", ")))
 	}
 	return strings.TrimRight(result.String(), "\n")
 }
 
+// generateMongooseSubSchemas declares a sub-schema for every nested object
+// field, innermost first, so the module schema can reference them.
+// Single objects get no _id of their own; array items keep theirs.
+func generateMongooseSubSchemas(moduleName string, fields []types.Field) string {
+	var result strings.Builder
+	walkObjects(toPascalCase(moduleName), fields, func(name string, f types.Field) {
+		options := ""
+		if !f.Array {
+			options = ",\n    { _id: false }"
+		}
+		result.WriteString(fmt.Sprintf("const %sSchema = new Schema(\n    {\n%s\n    }%s\n);\n\n", name, mongooseFieldLines(name, f.Fields, "        "), options))
+	})
+	return strings.TrimRight(result.String(), "\n")
+}
+
+// mongooseFieldOptions returns the schema type options of a field. For lists
+// the per-item options are wrapped in an array type.
+func mongooseFieldOptions(prefix string, f types.Field) []string {
+	item := []string{"type: " + mapTypeToMongoose(f.Type)}
+	switch f.Type {
+	case "E":
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = strconv.Quote(value)
+		}
+		item[0] = "type: String"
+		item = append(item, "enum: ["+strings.Join(values, ", ")+"]")
+	case "REF":
+		item[0] = "type: Schema.Types.ObjectId"
+		item = append(item, "ref: "+strconv.Quote(f.Ref))
+	case "S":
+		if f.Length != "" {
+			item = append(item, "minlength: "+f.Length, "maxlength: "+f.Length)
+		}
+		if f.Min != "" {
+			item = append(item, "minlength: "+f.Min)
+		}
+		if f.Max != "" {
+			item = append(item, "maxlength: "+f.Max)
+		}
+		if f.Pattern != "" {
+			item = append(item, "match: "+regexLiteral(f.Pattern))
+		}
+	case "N":
+		if f.Min != "" {
+			item = append(item, "min: "+f.Min)
+		}
+		if f.Max != "" {
+			item = append(item, "max: "+f.Max)
+		}
+	case "O":
+		item[0] = "type: " + prefix + toPascalCase(f.Name) + "Schema"
+	}
+
+	options := item
+	switch {
+	case f.Array && f.Type == "O":
+		options = []string{"type: [" + strings.TrimPrefix(item[0], "type: ") + "]"}
+	case f.Array:
+		options = []string{"type: [{ " + strings.Join(item, ", ") + " }]"}
+	}
+	options = append(options, fmt.Sprintf("required: %t", f.Required))
+	if f.Unique {
+		options = append(options, "unique: true")
+	}
+	if f.Index {
+		options = append(options, "index: true")
+	}
+	if f.Default != "" {
+		options = append(options, "default: "+mongooseDefault(f))
+	}
+	return options
+}
+
+func mongooseDefault(f types.Field) string {
+	if f.Type == "D" {
+		if f.Default == "now" {
+			return "Date.now"
+		}
+		return fmt.Sprintf("() => new Date(%s)", strconv.Quote(f.Default))
+	}
+	return literal(f)
+}
+
 func generateZodSchema(moduleName string, fields []types.Field) string {
 	if len(fields) == 0 {
 		return "export const " + toPascalCase(moduleName) + "Schema = z.object({\n  // Add your fields here\n});"
 	}
 	var result strings.Builder
 	result.WriteString("export const " + toPascalCase(moduleName) + "Schema = z.object({\n")
+	result.WriteString(zodObjectBody(fields, "  "))
+	result.WriteString("\n});")
+	return result.String()
+}
+
+func zodObjectBody(fields []types.Field, indent string) string {
+	var result strings.Builder
 	for _, f := range fields {
-		zodType := mapTypeToZod(f.Type)
-		if !f.Required {
-			zodType += ".optional()"
+		result.WriteString(fmt.Sprintf("%s%s: %s,\n", indent, f.Name, fieldZod(f, indent)))
+	}
+	return strings.TrimRight(result.String(), "\n")
+}
+
+// fieldZod returns the Zod validator of a field with its constraints,
+// default and optionality applied. Nested objects are written inline, one
+// level deeper than indent.
+func fieldZod(f types.Field, indent string) string {
+	zodType := mapTypeToZod(f.Type)
+	switch f.Type {
+	case "O":
+		zodType = "z.object({\n" + zodObjectBody(f.Fields, indent+"  ") + "\n" + indent + "})"
+	case "E":
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = strconv.Quote(value)
+		}
+		zodType = "z.enum([" + strings.Join(values, ", ") + "])"
+	case "REF":
+		zodType = `z.string().regex(/^[0-9a-fA-F]{24}$/, "Invalid id")`
+	case "S", "N":
+		if f.Min != "" {
+			zodType += ".min(" + f.Min + ")"
+		}
+		if f.Max != "" {
+			zodType += ".max(" + f.Max + ")"
+		}
+		if f.Length != "" {
+			zodType += ".length(" + f.Length + ")"
+		}
+		if f.Pattern != "" {
+			zodType += ".regex(" + regexLiteral(f.Pattern) + ")"
 		}
-		result.WriteString(fmt.Sprintf("  %s: %s,\n", f.Name, zodType))
 	}
-	result.WriteString("});")
-	return result.String()
+	if f.Array {
+		zodType = "z.array(" + zodType + ")"
+	}
+
+	switch {
+	case f.Default != "" && f.Type == "D":
+		if f.Default == "now" {
+			zodType += ".default(() => new Date())"
+		} else {
+			zodType += fmt.Sprintf(".default(() => new Date(%s))", strconv.Quote(f.Default))
+		}
+	case f.Default != "":
+		zodType += ".default(" + literal(f) + ")"
+	case !f.Required:
+		zodType += ".optional()"
+	}
+	return zodType
+}
+
+// literal renders a field's default value as a JavaScript literal.
+func literal(f types.Field) string {
+	switch f.Type {
+	case "N", "B":
+		return f.Default
+	default:
+		return strconv.Quote(f.Default)
+	}
+}
+
+// regexLiteral renders pattern as a JavaScript regular expression literal.
+func regexLiteral(pattern string) string {
+	return "/" + strings.ReplaceAll(pattern, "/", `\/`) + "/"
 }
 
 func generateZodTypes(moduleName string) string {
@@ -841,7 +2397,19 @@ func generateZodExports(moduleName string) string {
 	return fmt.Sprintf("export { %sSchema };", toPascalCase(moduleName))
 }
 
+// typeMapping returns the config's override for a field type code, if any.
+func typeMapping(t string) (types.TypeMapping, bool) {
+	if currentConfig == nil {
+		return types.TypeMapping{}, false
+	}
+	mapping, ok := currentConfig.TypeMappings[t]
+	return mapping, ok
+}
+
 func mapTypeToTypeScript(t string) string {
+	if mapping, ok := typeMapping(t); ok && mapping.TypeScript != "" {
+		return mapping.TypeScript
+	}
 	switch t {
 	case "S":
 		return "string"
@@ -857,6 +2425,9 @@ func mapTypeToTypeScript(t string) string {
 }
 
 func mapTypeToMongoose(t string) string {
+	if mapping, ok := typeMapping(t); ok && mapping.Mongoose != "" {
+		return mapping.Mongoose
+	}
 	switch t {
 	case "S":
 		return "String"
@@ -871,7 +2442,38 @@ func mapTypeToMongoose(t string) string {
 	}
 }
 
+// mapTypeToInputType returns the HTML input type used for a field in
+// generated frontend forms.
+func mapTypeToInputType(t string) string {
+	switch t {
+	case "N":
+		return "number"
+	case "B":
+		return "checkbox"
+	case "D":
+		return "date"
+	default:
+		return "text"
+	}
+}
+
+// mapTypeToRegisterOptions returns the react-hook-form register options that
+// make an input produce the value type the Zod schema expects.
+func mapTypeToRegisterOptions(t string) string {
+	switch t {
+	case "N":
+		return ", { valueAsNumber: true }"
+	case "D":
+		return ", { valueAsDate: true }"
+	default:
+		return ""
+	}
+}
+
 func mapTypeToZod(t string) string {
+	if mapping, ok := typeMapping(t); ok && mapping.Zod != "" {
+		return mapping.Zod
+	}
 	switch t {
 	case "S":
 		return "z.string()"
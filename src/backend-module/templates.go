@@ -0,0 +1,20 @@
+package backendmodule
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/sohel902833/go_super_cli/src/templates"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+func loadTemplateSet(set, overrideDir string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
+	defaults, err := fs.Sub(templateFS, "templates/"+set)
+	if err != nil {
+		return nil, nil, err
+	}
+	return templates.Load(defaults, overrideDir)
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// Version is the config schema version written by this CLI. Configs are
+// accepted as long as they share its major version.
+const Version = "1.0.0"
+
+// Load reads a project config from path and checks that its schema version
+// is one this CLI understands.
+func Load(path string) (*types.ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var config types.ProjectConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, describeJSONError(data, err))
+	}
+
+	if err := checkVersion(config.Version); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+func checkVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("missing \"version\" (expected %s)", Version)
+	}
+	major, _, _ := strings.Cut(version, ".")
+	supported, _, _ := strings.Cut(Version, ".")
+	if major != supported {
+		return fmt.Errorf("unsupported config version %q (this CLI supports %s.x)", version, supported)
+	}
+	return nil
+}
+
+// describeJSONError adds a line and column to JSON syntax and type errors,
+// which encoding/json only reports as a byte offset.
+func describeJSONError(data []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	line, col := 1, 1
+	for _, b := range data[:min(int(offset), len(data))] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	// FileName is the project config file looked up by Discover.
+	FileName = "super.config.json"
+	// DirName is the per-project directory that can hold config.json and
+	// other generator state.
+	DirName = ".super"
+)
+
+// Discover walks up from dir looking for a super.config.json file or a .super
+// directory, the way git looks for .git. It returns the directory where one
+// was found and the config file inside it, if there is one. Both are empty
+// when nothing was found before reaching the filesystem root.
+func Discover(dir string) (root string, path string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		candidate := filepath.Join(dir, FileName)
+		if isFile(candidate) {
+			return dir, candidate, nil
+		}
+
+		superDir := filepath.Join(dir, DirName)
+		if info, err := os.Stat(superDir); err == nil && info.IsDir() {
+			candidate = filepath.Join(superDir, "config.json")
+			if isFile(candidate) {
+				return dir, candidate, nil
+			}
+			return dir, "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+var (
+	tokenPattern = regexp.MustCompile(`\{\{([A-Z][A-Z0-9_]*)\}\}`)
+	// blockPattern matches a marker block name; the built-in ones use
+	// hyphens, as in "route-import".
+	blockPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	// targetPattern matches a top-level declaration, optionally followed by
+	// property names, as in "router.children".
+	targetPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+)
+
+// Issue is a single problem found while validating a config.
+type Issue struct {
+	Location string
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Location, i.Message)
+}
+
+// Validate lints a config for mistakes that would only show up at generation
+// time: duplicate file paths, {{TOKENS}} that are never replaced, updates
+// with nowhere to go and update positions, blocks or targets the generator
+// does not understand. knownTokens lists the token
+// names (without braces) the generator fills in.
+func Validate(config *types.ProjectConfig, knownTokens []string) []Issue {
+	known := make(map[string]bool, len(knownTokens))
+	for _, token := range knownTokens {
+		known[token] = true
+	}
+
+	var issues []Issue
+	if err := checkVersion(config.Version); err != nil {
+		issues = append(issues, Issue{Location: "version", Message: err.Error()})
+	}
+	issues = append(issues, validateFiles("fileInstructions", config.FileInstructions, known)...)
+	issues = append(issues, validateUpdates("updateInstructions", config.UpdateInstructions, known)...)
+	issues = append(issues, validateFiles("initFileInstructions", config.InitFileInstructions, known)...)
+	issues = append(issues, validateUpdates("initUpdateInstructions", config.InitUpdateInstructions, known)...)
+	return issues
+}
+
+func validateFiles(section string, instructions []types.FileInstruction, known map[string]bool) []Issue {
+	var issues []Issue
+	seen := make(map[string]int, len(instructions))
+	for i, instruction := range instructions {
+		location := fmt.Sprintf("%s[%d]", section, i)
+		if instruction.FilePath == "" {
+			issues = append(issues, Issue{Location: location, Message: "filePath is empty"})
+		} else if first, ok := seen[instruction.FilePath]; ok {
+			issues = append(issues, Issue{
+				Location: location,
+				Message:  fmt.Sprintf("duplicate filePath %q (also used by %s[%d])", instruction.FilePath, section, first),
+			})
+		} else {
+			seen[instruction.FilePath] = i
+		}
+		issues = append(issues, unknownTokens(location+".filePath", instruction.FilePath, known)...)
+		issues = append(issues, unknownTokens(location+".content", instruction.Content, known)...)
+	}
+	return issues
+}
+
+func validateUpdates(section string, instructions []types.UpdateInstruction, known map[string]bool) []Issue {
+	var issues []Issue
+	for i, instruction := range instructions {
+		location := fmt.Sprintf("%s[%d]", section, i)
+		if instruction.FilePath == "" {
+			issues = append(issues, Issue{Location: location, Message: "filePath is empty"})
+		}
+		if instruction.Placeholder == "" && instruction.Target == "" {
+			issues = append(issues, Issue{Location: location, Message: "placeholder and target are both empty; set at least one"})
+		}
+		if instruction.Target != "" && !targetPattern.MatchString(instruction.Target) {
+			issues = append(issues, Issue{
+				Location: location + ".target",
+				Message:  fmt.Sprintf("invalid target %q (expected \"import\" or a declaration such as \"router.children\")", instruction.Target),
+			})
+		}
+		if instruction.Block != "" && !blockPattern.MatchString(instruction.Block) {
+			issues = append(issues, Issue{
+				Location: location + ".block",
+				Message:  fmt.Sprintf("invalid block name %q (expected letters, digits, '_' or '-')", instruction.Block),
+			})
+		}
+		switch instruction.Position {
+		case "", "top", "bottom":
+		default:
+			issues = append(issues, Issue{
+				Location: location + ".position",
+				Message:  fmt.Sprintf("unknown position %q (expected \"top\" or \"bottom\")", instruction.Position),
+			})
+		}
+		issues = append(issues, unknownTokens(location+".filePath", instruction.FilePath, known)...)
+		issues = append(issues, unknownTokens(location+".placeholder", instruction.Placeholder, known)...)
+		issues = append(issues, unknownTokens(location+".content", instruction.Content, known)...)
+	}
+	return issues
+}
+
+func unknownTokens(location, text string, known map[string]bool) []Issue {
+	var issues []Issue
+	reported := map[string]bool{}
+	for _, match := range tokenPattern.FindAllStringSubmatch(text, -1) {
+		token := match[1]
+		if known[token] || reported[token] {
+			continue
+		}
+		reported[token] = true
+		issues = append(issues, Issue{Location: location, Message: fmt.Sprintf("unknown token {{%s}}", token)})
+	}
+	return issues
+}
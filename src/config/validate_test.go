@@ -0,0 +1,38 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+func TestValidateUpdates(t *testing.T) {
+	tests := []struct {
+		update types.UpdateInstruction
+		want   []string
+	}{
+		{types.UpdateInstruction{FilePath: "a.ts", Placeholder: "//HERE"}, nil},
+		{types.UpdateInstruction{FilePath: "a.ts", Target: "import"}, nil},
+		{types.UpdateInstruction{FilePath: "a.ts", Target: "router.children", Block: "route-import"}, nil},
+		{types.UpdateInstruction{FilePath: "a.ts"}, []string{
+			"updateInstructions[0]: placeholder and target are both empty; set at least one",
+		}},
+		{types.UpdateInstruction{FilePath: "a.ts", Target: "router children"}, []string{
+			`updateInstructions[0].target: invalid target "router children" (expected "import" or a declaration such as "router.children")`,
+		}},
+		{types.UpdateInstruction{FilePath: "a.ts", Placeholder: "//HERE", Block: "route:order"}, []string{
+			`updateInstructions[0].block: invalid block name "route:order" (expected letters, digits, '_' or '-')`,
+		}},
+	}
+	for _, tt := range tests {
+		config := &types.ProjectConfig{Version: Version, UpdateInstructions: []types.UpdateInstruction{tt.update}}
+		var got []string
+		for _, issue := range Validate(config, nil) {
+			got = append(got, issue.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Validate(%+v) = %q, want %q", tt.update, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package diff compares files line by line and merges two edited versions
+// of a file against their common base.
+package diff
+
+import "strings"
+
+// Hunk replaces the base lines [Start, End) with Lines. Start == End is a
+// pure insertion.
+type Hunk struct {
+	Start, End int
+	Lines      []string
+}
+
+// SplitLines splits s into lines that keep their trailing newline, so
+// joining them gives s back.
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Hunks returns the changes that turn the lines of a into those of b, in
+// order, by a longest common subsequence of lines.
+func Hunks(a, b []string) []Hunk {
+	// common[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var hunks []Hunk
+	var current *Hunk
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if i < len(a) && j < len(b) && a[i] == b[j] {
+			if current != nil {
+				hunks = append(hunks, *current)
+				current = nil
+			}
+			i++
+			j++
+			continue
+		}
+		if current == nil {
+			current = &Hunk{Start: i, End: i}
+		}
+		if j < len(b) && (i == len(a) || common[i][j+1] >= common[i+1][j]) {
+			current.Lines = append(current.Lines, b[j])
+			j++
+		} else {
+			i++
+			current.End = i
+		}
+	}
+	if current != nil {
+		hunks = append(hunks, *current)
+	}
+	return hunks
+}
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Labels name the two sides in conflict markers.
+type Labels struct {
+	Ours, Theirs string
+}
+
+// Merge combines the changes ours and theirs each made to base. Where both
+// changed the same or adjacent lines differently, the result holds both
+// versions between git-style conflict markers, and conflicts counts them.
+func Merge(base, ours, theirs string, labels Labels) (merged string, conflicts int) {
+	baseLines := SplitLines(base)
+	type change struct {
+		Hunk
+		theirs bool
+	}
+	var changes []change
+	for _, h := range Hunks(baseLines, SplitLines(ours)) {
+		changes = append(changes, change{h, false})
+	}
+	for _, h := range Hunks(baseLines, SplitLines(theirs)) {
+		changes = append(changes, change{h, true})
+	}
+	slices.SortStableFunc(changes, func(a, b change) int { return a.Start - b.Start })
+
+	var out strings.Builder
+	at := 0
+	for i := 0; i < len(changes); {
+		// Group the changes that overlap or touch
+		lo, hi := changes[i].Start, changes[i].End
+		var ourHunks, theirHunks []Hunk
+		for ; i < len(changes) && changes[i].Start <= hi; i++ {
+			hi = max(hi, changes[i].End)
+			if changes[i].theirs {
+				theirHunks = append(theirHunks, changes[i].Hunk)
+			} else {
+				ourHunks = append(ourHunks, changes[i].Hunk)
+			}
+		}
+
+		writeLines(&out, baseLines[at:lo])
+		at = hi
+		ourVersion := apply(baseLines, lo, hi, ourHunks)
+		theirVersion := apply(baseLines, lo, hi, theirHunks)
+		switch {
+		case len(theirHunks) == 0 || slices.Equal(ourVersion, theirVersion):
+			writeLines(&out, ourVersion)
+		case len(ourHunks) == 0:
+			writeLines(&out, theirVersion)
+		default:
+			conflicts++
+			writeMarker(&out, "<<<<<<< "+labels.Ours)
+			writeLines(&out, ourVersion)
+			writeMarker(&out, "=======")
+			writeLines(&out, theirVersion)
+			writeMarker(&out, ">>>>>>> "+labels.Theirs)
+		}
+	}
+	writeLines(&out, baseLines[at:])
+	return out.String(), conflicts
+}
+
+// apply returns the base lines [lo, hi) with hunks, which fall inside that
+// range, applied.
+func apply(base []string, lo, hi int, hunks []Hunk) []string {
+	var lines []string
+	at := lo
+	for _, h := range hunks {
+		lines = append(lines, base[at:h.Start]...)
+		lines = append(lines, h.Lines...)
+		at = h.End
+	}
+	return append(lines, base[at:hi]...)
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeMarker writes a conflict marker on a line of its own.
+func writeMarker(out *strings.Builder, marker string) {
+	if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+		out.WriteString("\n")
+	}
+	out.WriteString(marker + "\n")
+}
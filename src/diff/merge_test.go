@@ -0,0 +1,38 @@
+package diff
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	const base = "a\nb\nc\nd\ne\n"
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               string
+		conflicts          int
+	}{
+		{"unchanged", base, base, base, base, 0},
+		{"only ours", base, "a\nB\nc\nd\ne\n", base, "a\nB\nc\nd\ne\n", 0},
+		{"only theirs", base, base, "a\nc\n", "a\nc\n", 0},
+		{"separate lines", base, "a\nB\nc\nd\ne\n", "a\nb\nc\nd\nE\n", "a\nB\nc\nd\nE\n", 0},
+		{"same change", base, "a\nB\nc\nd\ne\n", "a\nB\nc\nd\ne\n", "a\nB\nc\nd\ne\n", 0},
+		{"insert and append", base, base + "z\n", "a\nb\nnew\nc\nd\ne\n", "a\nb\nnew\nc\nd\ne\nz\n", 0},
+		{"same line", base, "a\nB\nc\nd\ne\n", "a\nX\nc\nd\ne\n",
+			"a\n<<<<<<< current\nB\n=======\nX\n>>>>>>> generated\nc\nd\ne\n", 1},
+		{"adjacent lines", base, "a\nB\nc\nd\ne\n", "a\nb\nC\nd\ne\n",
+			"a\n<<<<<<< current\nB\nc\n=======\nb\nC\n>>>>>>> generated\nd\ne\n", 1},
+		{"insertions at one point", base, "a\nb\nx\nc\nd\ne\n", "a\nb\ny\nc\nd\ne\n",
+			"a\nb\n<<<<<<< current\nx\n=======\ny\n>>>>>>> generated\nc\nd\ne\n", 1},
+		{"two conflicts", base, "A\nb\nc\nd\nE\n", "1\nb\nc\nd\n5\n",
+			"<<<<<<< current\nA\n=======\n1\n>>>>>>> generated\nb\nc\nd\n<<<<<<< current\nE\n=======\n5\n>>>>>>> generated\n", 2},
+		{"no trailing newline", "a\nb", "a\nc", "a\nd",
+			"a\n<<<<<<< current\nc\n=======\nd\n>>>>>>> generated\n", 1},
+		{"empty base", "", "x\n", "x\n", "x\n", 0},
+	}
+	labels := Labels{Ours: "current", Theirs: "generated"}
+	for _, tt := range tests {
+		got, conflicts := Merge(tt.base, tt.ours, tt.theirs, labels)
+		if got != tt.want || conflicts != tt.conflicts {
+			t.Errorf("%s: Merge = %q, %d conflicts; want %q, %d", tt.name, got, conflicts, tt.want, tt.conflicts)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// contextLines is how many unchanged lines surround each change in a
+// unified diff.
+const contextLines = 3
+
+// Unified returns the changes from a to b as a unified diff with the file
+// names fromName and toName, or "" when they are equal.
+func Unified(a, b, fromName, toName string) string {
+	aLines, bLines := SplitLines(a), SplitLines(b)
+	hunks := Hunks(aLines, bLines)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(hunks); {
+		// Join changes whose context would overlap into one section
+		j := i + 1
+		for j < len(hunks) && hunks[j].Start-hunks[j-1].End <= 2*contextLines {
+			j++
+		}
+		section := hunks[i:j]
+		i = j
+
+		start := max(section[0].Start-contextLines, 0)
+		end := min(section[len(section)-1].End+contextLines, len(aLines))
+		// offset converts a line of a into the matching line of b
+		offset := 0
+		for _, h := range hunks {
+			if h.Start >= section[0].Start {
+				break
+			}
+			offset += len(h.Lines) - (h.End - h.Start)
+		}
+		added := 0
+		for _, h := range section {
+			added += len(h.Lines) - (h.End - h.Start)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", lineRange(start, end-start), lineRange(start+offset, end-start+added))
+
+		at := start
+		for _, h := range section {
+			writePrefixed(&out, " ", aLines[at:h.Start])
+			writePrefixed(&out, "-", aLines[h.Start:h.End])
+			writePrefixed(&out, "+", h.Lines)
+			at = h.End
+		}
+		writePrefixed(&out, " ", aLines[at:end])
+	}
+	return out.String()
+}
+
+// lineRange formats a hunk range as "start,count", with 1-based lines.
+func lineRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writePrefixed(out *strings.Builder, prefix string, lines []string) {
+	for _, line := range lines {
+		out.WriteString(prefix + line)
+		if !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+const (
+	bold  = "\x1b[1m"
+	red   = "\x1b[31m"
+	green = "\x1b[32m"
+	cyan  = "\x1b[36m"
+	reset = "\x1b[0m"
+)
+
+// Colorize adds terminal colors to a unified diff: file headers in bold,
+// hunk headers in cyan, removed lines in red and added lines in green.
+func Colorize(unified string) string {
+	var out strings.Builder
+	oldLeft, newLeft := 0, 0
+	for _, line := range SplitLines(unified) {
+		text := strings.TrimSuffix(line, "\n")
+		color := ""
+		switch {
+		case (oldLeft > 0 || newLeft > 0) && text != "":
+			switch text[0] {
+			case '-':
+				color = red
+				oldLeft--
+			case '+':
+				color = green
+				newLeft--
+			case ' ':
+				oldLeft--
+				newLeft--
+			}
+		case strings.HasPrefix(text, "@@ "):
+			color = cyan
+			oldLeft, newLeft = hunkCounts(text)
+		case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "+++ "):
+			color = bold
+		}
+		if color == "" {
+			out.WriteString(line)
+			continue
+		}
+		out.WriteString(color + text + reset + strings.TrimPrefix(line, text))
+	}
+	return out.String()
+}
+
+// hunkCounts reads the old and new line counts of a hunk header such as
+// "@@ -1,5 +1,6 @@".
+func hunkCounts(header string) (int, int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0
+	}
+	return rangeCount(fields[1]), rangeCount(fields[2])
+}
+
+func rangeCount(r string) int {
+	_, count, found := strings.Cut(r, ",")
+	if !found {
+		return 1
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package fields
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+var typeNames = map[string]string{
+	"string":   "S",
+	"number":   "N",
+	"boolean":  "B",
+	"date":     "D",
+	"enum":     "E",
+	"ref":      "REF",
+	"objectid": "REF",
+	"object":   "O",
+}
+
+// FromDefinitions converts the structured field definitions of an upload
+// file into fields, applying the same checks as Parse. Errors name the
+// offending field by its path, e.g. fields[2].fields[0] (street).
+func FromDefinitions(definitions []types.FieldDefinition, customTypes ...string) ([]types.Field, error) {
+	return fromDefinitions(definitions, "fields", true, append(slices.Clone(builtinTypes), customTypes...))
+}
+
+func fromDefinitions(definitions []types.FieldDefinition, path string, top bool, known []string) ([]types.Field, error) {
+	fields := make([]types.Field, 0, len(definitions))
+	for i, definition := range definitions {
+		fieldPath := fmt.Sprintf("%s[%d]", path, i)
+		if definition.Name != "" {
+			fieldPath += fmt.Sprintf(" (%s)", definition.Name)
+		}
+		if err := CheckName(definition.Name, top); err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldPath, err)
+		}
+		if slices.ContainsFunc(fields, func(f types.Field) bool { return f.Name == definition.Name }) {
+			return nil, fmt.Errorf("%s: duplicate field %q", fieldPath, definition.Name)
+		}
+		field, err := fromDefinition(definition, known)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldPath, err)
+		}
+		if field.Type == "O" {
+			if field.Fields, err = fromDefinitions(definition.Fields, fmt.Sprintf("%s[%d].fields", path, i), false, known); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// fromDefinition converts a single definition. The children of an object
+// are left to the caller.
+func fromDefinition(definition types.FieldDefinition, known []string) (types.Field, error) {
+	field := types.Field{
+		Name:        definition.Name,
+		Required:    definition.Required,
+		Array:       definition.Array,
+		Enum:        definition.Enum,
+		Ref:         definition.Ref,
+		Description: definition.Description,
+		Pattern:     definition.Validations.Pattern,
+		Unique:      definition.Validations.Unique,
+		Index:       definition.Validations.Index,
+		Min:         number(definition.Validations.Min),
+		Max:         number(definition.Validations.Max),
+		Length:      number(definition.Validations.Length),
+	}
+	switch value := definition.Default.(type) {
+	case nil:
+	case time.Time:
+		// YAML reads unquoted dates and timestamps as times
+		field.Default = value.Format(time.RFC3339)
+		if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 && value.Nanosecond() == 0 {
+			field.Default = value.Format(time.DateOnly)
+		}
+	default:
+		field.Default = fmt.Sprint(definition.Default)
+	}
+
+	typeName := strings.TrimSpace(definition.Type)
+	if rest, ok := strings.CutSuffix(typeName, "[]"); ok {
+		field.Array = true
+		typeName = rest
+	}
+	field.Type = typeName
+	if code, ok := typeNames[strings.ToLower(typeName)]; ok {
+		field.Type = code
+	}
+
+	switch field.Type {
+	case "":
+		return types.Field{}, fmt.Errorf("missing type")
+	case "E":
+		if slices.ContainsFunc(field.Enum, func(v string) bool { return strings.TrimSpace(v) == "" }) {
+			return types.Field{}, fmt.Errorf("empty enum value")
+		}
+		if len(field.Enum) == 0 {
+			return types.Field{}, fmt.Errorf("enum needs at least one value")
+		}
+	case "REF":
+		if field.Ref == "" {
+			return types.Field{}, fmt.Errorf("ref needs a model name")
+		}
+	case "O":
+		if len(definition.Fields) == 0 {
+			return types.Field{}, fmt.Errorf("object needs at least one field")
+		}
+	default:
+		if !slices.Contains(known, field.Type) {
+			return types.Field{}, fmt.Errorf("unknown type %q", definition.Type)
+		}
+	}
+	if field.Type != "E" && len(field.Enum) > 0 {
+		return types.Field{}, fmt.Errorf("enum values given for a %s field", definition.Type)
+	}
+	if field.Type != "REF" && field.Ref != "" {
+		return types.Field{}, fmt.Errorf("ref given for a %s field", definition.Type)
+	}
+	if field.Type != "O" && len(definition.Fields) > 0 {
+		return types.Field{}, fmt.Errorf("nested fields given for a %s field", definition.Type)
+	}
+	return field, checkField(field)
+}
+
+func number(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// Definitions converts fields back into structured definitions, the form
+// FromDefinitions reads.
+func Definitions(fields []types.Field) []types.FieldDefinition {
+	definitions := make([]types.FieldDefinition, 0, len(fields))
+	for _, f := range fields {
+		definition := types.FieldDefinition{
+			Name:        f.Name,
+			Type:        f.Type,
+			Required:    f.Required,
+			Array:       f.Array,
+			Enum:        f.Enum,
+			Ref:         f.Ref,
+			Description: f.Description,
+			Validations: types.Validations{
+				Min:     parseNumber(f.Min),
+				Max:     parseNumber(f.Max),
+				Length:  parseNumber(f.Length),
+				Pattern: f.Pattern,
+				Unique:  f.Unique,
+				Index:   f.Index,
+			},
+			Fields: Definitions(f.Fields),
+		}
+		if f.Default != "" {
+			definition.Default = f.Default
+		}
+		if len(f.Fields) == 0 {
+			definition.Fields = nil
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions
+}
+
+func parseNumber(value string) *float64 {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,32 @@
+package fields
+
+import (
+	"testing"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFromDefinitionsDefaults(t *testing.T) {
+	var definitions []types.FieldDefinition
+	err := yaml.Unmarshal([]byte(`
+- {name: day, type: date, default: 2024-01-01}
+- {name: at, type: date, default: 2024-01-01T10:30:00Z}
+- {name: count, type: number, default: 3}
+- {name: active, type: boolean, default: true}
+- {name: label, type: string, default: "2024-01-01"}
+`), &definitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := FromDefinitions(definitions)
+	if err != nil {
+		t.Fatalf("FromDefinitions: %v", err)
+	}
+	want := []string{"2024-01-01", "2024-01-01T10:30:00Z", "3", "true", "2024-01-01"}
+	for i, f := range fields {
+		if f.Default != want[i] {
+			t.Errorf("%s default = %q, want %q", f.Name, f.Default, want[i])
+		}
+	}
+}
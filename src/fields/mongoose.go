@@ -0,0 +1,417 @@
+package fields
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/tslex"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+type valueKind int
+
+const (
+	valueOther valueKind = iota
+	valueObject
+	valueArray
+	valueName
+	valueString
+	valueNumber
+	valueBool
+	valueRegex
+	valueNew
+)
+
+// value is a JavaScript expression as far as schema definitions need it.
+type value struct {
+	kind  valueKind
+	text  string   // dotted name, constructor or literal
+	keys  []string // object keys, parallel to items
+	items []*value // object values, array items or constructor arguments
+}
+
+func (v *value) get(key string) *value {
+	for i, k := range v.keys {
+		if k == key {
+			return v.items[i]
+		}
+	}
+	return nil
+}
+
+func parseValue(s *tslex.Stream) *value {
+	t := s.Peek()
+	switch {
+	case s.Is("{"):
+		s.Next()
+		v := &value{kind: valueObject}
+		for !s.Is("}") && s.Peek().Kind != tslex.EOF {
+			key := s.Next()
+			if key.Text == "..." || key.Kind == tslex.Punct {
+				s.SkipBalanced()
+			} else if s.Accept(":") {
+				v.keys = append(v.keys, key.Text)
+				v.items = append(v.items, parseValue(s))
+			} else if s.Is(",") || s.Is("}") {
+				v.keys = append(v.keys, key.Text)
+				v.items = append(v.items, &value{kind: valueName, text: key.Text})
+			} else {
+				s.SkipBalanced()
+			}
+			s.Accept(",")
+		}
+		s.Accept("}")
+		return endValue(s, v)
+	case s.Is("["):
+		s.Next()
+		v := &value{kind: valueArray}
+		for !s.Is("]") && s.Peek().Kind != tslex.EOF {
+			start := s.Index()
+			v.items = append(v.items, parseValue(s))
+			s.Accept(",")
+			skipIfStuck(s, start)
+		}
+		s.Accept("]")
+		return endValue(s, v)
+	case t.Kind == tslex.String:
+		s.Next()
+		return endValue(s, &value{kind: valueString, text: t.Text})
+	case t.Kind == tslex.Number:
+		s.Next()
+		return endValue(s, &value{kind: valueNumber, text: t.Text})
+	case t.Kind == tslex.Regex:
+		s.Next()
+		return endValue(s, &value{kind: valueRegex, text: t.Text})
+	case s.Is("-") && s.PeekAt(1).Kind == tslex.Number:
+		s.Next()
+		return endValue(s, &value{kind: valueNumber, text: "-" + s.Next().Text})
+	case t.Kind == tslex.Ident && (t.Text == "true" || t.Text == "false"):
+		s.Next()
+		return endValue(s, &value{kind: valueBool, text: t.Text})
+	case t.Kind == tslex.Ident:
+		isNew := s.Accept("new")
+		name := dottedName(s)
+		if s.Is("<") {
+			s.SkipGroup("<", ">")
+		}
+		if !s.Is("(") {
+			return endValue(s, &value{kind: valueName, text: name})
+		}
+		s.Next()
+		v := &value{kind: valueOther, text: name}
+		if isNew {
+			v.kind = valueNew
+		}
+		for !s.Is(")") && s.Peek().Kind != tslex.EOF {
+			start := s.Index()
+			v.items = append(v.items, parseValue(s))
+			s.Accept(",")
+			skipIfStuck(s, start)
+		}
+		s.Accept(")")
+		return endValue(s, v)
+	}
+	s.SkipBalanced()
+	return &value{kind: valueOther}
+}
+
+// endValue returns v, or an opaque value when more of the expression
+// follows, as in `Date.now() + 1`.
+func endValue(s *tslex.Stream, v *value) *value {
+	if s.Is(",") || s.Is(";") || s.Is(")") || s.Is("]") || s.Is("}") || s.Peek().Kind == tslex.EOF {
+		return v
+	}
+	s.SkipBalanced()
+	return &value{kind: valueOther}
+}
+
+// skipIfStuck consumes the current token when a list loop made no progress
+// since start, as on the stray ";" in `[Number;]`, so malformed input
+// cannot stop the loop from ending.
+func skipIfStuck(s *tslex.Stream, start int) {
+	if s.Index() == start {
+		s.Next()
+	}
+}
+
+func dottedName(s *tslex.Stream) string {
+	name := s.Next().Text
+	for s.Is(".") && s.PeekAt(1).Kind == tslex.Ident {
+		s.Next()
+		name += "." + s.Next().Text
+	}
+	return name
+}
+
+func lastSegment(name string) string {
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+// isSchema reports whether v is a `new Schema({...})` expression.
+func isSchema(v *value) bool {
+	return v.kind == valueNew && lastSegment(v.text) == "Schema" && len(v.items) > 0 && v.items[0].kind == valueObject
+}
+
+// FromMongoose reads the fields of the Mongoose schema defined in src. When
+// the file defines several schemas, the one passed to model() is used, or
+// else the last one. Schemas referenced by name become nested objects.
+// Options the generator cannot express are skipped and reported in the
+// returned warnings.
+func FromMongoose(src string) ([]types.Field, []string, error) {
+	tokens, err := tslex.Lex(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := tslex.NewStream(tokens)
+	schemas := map[string]*value{}
+	var last, modelSchema *value
+	for s.Peek().Kind != tslex.EOF {
+		t := s.Next()
+		switch {
+		case t.Kind == tslex.Ident && (t.Text == "const" || t.Text == "let" || t.Text == "var"):
+			name := s.Next().Text
+			if s.Accept(":") {
+				s.SkipType()
+			}
+			if s.Accept("=") {
+				if v := parseValue(s); isSchema(v) {
+					schemas[name] = v.items[0]
+					last = v.items[0]
+				}
+			}
+		case t.Kind == tslex.Ident && t.Text == "model" && (s.Is("(") || s.Is("<")):
+			s.Back()
+			call := parseValue(s)
+			if len(call.items) < 2 {
+				continue
+			}
+			if schema, ok := schemas[call.items[1].text]; ok && call.items[1].kind == valueName {
+				modelSchema = schema
+			} else if isSchema(call.items[1]) {
+				modelSchema = call.items[1].items[0]
+			}
+		case t.Kind == tslex.Ident && t.Text == "new" && s.Peek().Kind == tslex.Ident:
+			s.Back()
+			if v := parseValue(s); isSchema(v) && last == nil {
+				last = v.items[0]
+			}
+		}
+	}
+
+	schema := modelSchema
+	if schema == nil {
+		schema = last
+	}
+	if schema == nil {
+		return nil, nil, fmt.Errorf("no `new Schema({...})` definition found")
+	}
+	r := &mongooseReader{schemas: schemas}
+	fields := r.object(schema, "", true)
+	if len(fields) == 0 {
+		return nil, r.warnings, fmt.Errorf("the schema has no fields the generator can use")
+	}
+	return fields, r.warnings, nil
+}
+
+type mongooseReader struct {
+	schemas  map[string]*value
+	warnings []string
+}
+
+func (r *mongooseReader) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *mongooseReader) object(v *value, path string, top bool) []types.Field {
+	var fields []types.Field
+	for i, key := range v.keys {
+		if err := CheckName(key, top); err != nil {
+			r.warnf("skipped %s%s: %v", path, key, err)
+			continue
+		}
+		if field, ok := r.field(key, path+key, v.items[i]); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// field converts one schema path definition: a type, an array of one, a
+// nested object or an options object with a type key.
+func (r *mongooseReader) field(name, path string, v *value) (types.Field, bool) {
+	switch v.kind {
+	case valueArray:
+		if len(v.items) == 0 {
+			r.warnf("skipped %s: untyped arrays are not supported", path)
+			return types.Field{}, false
+		}
+		field, ok := r.field(name, path, v.items[0])
+		if ok && field.Array {
+			r.warnf("skipped %s: nested arrays are not supported", path)
+			return types.Field{}, false
+		}
+		field.Array = true
+		field.Required = false
+		return field, ok
+	case valueObject:
+		if v.get("type") == nil {
+			children := r.object(v, path+".", false)
+			if len(children) == 0 {
+				r.warnf("skipped %s: no usable fields", path)
+				return types.Field{}, false
+			}
+			return types.Field{Name: name, Type: "O", Fields: children}, true
+		}
+		field, ok := r.field(name, path, v.get("type"))
+		if !ok {
+			return field, false
+		}
+		r.options(&field, path, v)
+		return field, true
+	case valueName, valueNew:
+		if isSchema(v) {
+			return r.subSchema(name, path, v.items[0])
+		}
+		if schema, ok := r.schemas[v.text]; ok {
+			return r.subSchema(name, path, schema)
+		}
+		code := mongooseTypes[lastSegment(v.text)]
+		if code == "" {
+			r.warnf("skipped %s: unsupported type %s", path, v.text)
+			return types.Field{}, false
+		}
+		field := types.Field{Name: name, Type: code}
+		if code == "REF" {
+			field.Ref = toPascal(name)
+		}
+		return field, true
+	}
+	r.warnf("skipped %s: unsupported definition", path)
+	return types.Field{}, false
+}
+
+var mongooseTypes = map[string]string{
+	"String":     "S",
+	"Number":     "N",
+	"Decimal128": "N",
+	"Boolean":    "B",
+	"Date":       "D",
+	"ObjectId":   "REF",
+}
+
+func (r *mongooseReader) subSchema(name, path string, schema *value) (types.Field, bool) {
+	children := r.object(schema, path+".", false)
+	if len(children) == 0 {
+		r.warnf("skipped %s: no usable fields", path)
+		return types.Field{}, false
+	}
+	return types.Field{Name: name, Type: "O", Fields: children}, true
+}
+
+// options applies the schema type options of v, such as required, enum or
+// min, to field.
+func (r *mongooseReader) options(field *types.Field, path string, v *value) {
+	for i, key := range v.keys {
+		option := v.items[i]
+		switch key {
+		case "type":
+		case "required":
+			switch {
+			case option.kind == valueBool:
+				field.Required = option.text == "true"
+			case option.kind == valueArray && len(option.items) > 0 && option.items[0].kind == valueBool:
+				field.Required = option.items[0].text == "true"
+			default:
+				r.warnf("%s: conditional required is not supported", path)
+			}
+		case "unique":
+			field.Unique = option.kind == valueBool && option.text == "true"
+		case "index":
+			field.Index = option.kind == valueBool && option.text == "true"
+		case "ref":
+			switch option.kind {
+			case valueString:
+				field.Ref = option.text
+			case valueName:
+				// MODEL_NAMES.ORDER_ITEM style constants
+				field.Ref = toPascal(strings.ToLower(lastSegment(option.text)))
+				r.warnf("%s: ref %s assumed to name the %s model", path, option.text, field.Ref)
+			}
+		case "enum":
+			if field.Type != "S" || option.kind != valueArray {
+				r.warnf("%s: enum is only supported as a list of strings", path)
+				continue
+			}
+			var values []string
+			for _, item := range option.items {
+				if item.kind != valueString {
+					r.warnf("%s: enum is only supported as a list of strings", path)
+					values = nil
+					break
+				}
+				values = append(values, item.text)
+			}
+			if values != nil {
+				field.Type = "E"
+				field.Enum = values
+			}
+		case "default":
+			r.defaultValue(field, path, option)
+		case "min", "minlength", "max", "maxlength":
+			if option.kind != valueNumber || field.Type != "N" && field.Type != "S" {
+				r.warnf("%s: %s is not supported here", path, key)
+				continue
+			}
+			if strings.HasPrefix(key, "min") {
+				field.Min = option.text
+			} else {
+				field.Max = option.text
+			}
+		case "match":
+			if option.kind != valueRegex {
+				r.warnf("%s: match is only supported as a regular expression literal", path)
+				continue
+			}
+			end := strings.LastIndexByte(option.text, '/')
+			if end < len(option.text)-1 {
+				r.warnf("%s: regular expression flags %s dropped", path, option.text[end+1:])
+			}
+			field.Pattern = strings.ReplaceAll(option.text[1:end], `\/`, "/")
+		default:
+			r.warnf("%s: option %s dropped", path, key)
+		}
+	}
+}
+
+func (r *mongooseReader) defaultValue(field *types.Field, path string, option *value) {
+	switch {
+	case field.Array:
+		r.warnf("%s: defaults on lists are not supported", path)
+	case field.Type == "D" && option.kind == valueName && option.text == "Date.now":
+		field.Default = "now"
+	case option.kind == valueString && (field.Type == "S" || field.Type == "E" || field.Type == "D"),
+		option.kind == valueNumber && field.Type == "N",
+		option.kind == valueBool && field.Type == "B":
+		field.Default = option.text
+	default:
+		r.warnf("%s: default dropped, only literal defaults are supported", path)
+	}
+}
+
+func toPascal(s string) string {
+	var result strings.Builder
+	upper := true
+	for _, r := range s {
+		if r == '_' || r == '-' {
+			upper = true
+			continue
+		}
+		if upper {
+			result.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		} else {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,493 @@
+package fields
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// SyntaxError is a problem in a field definition string, reported at a
+// 1-based line and column.
+type SyntaxError struct {
+	Line   int
+	Column int
+	Msg    string
+	text   string // the offending line
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// Snippet returns the offending line with a caret under the error position.
+func (e *SyntaxError) Snippet() string {
+	return e.text + "\n" + strings.Repeat(" ", e.Column-1) + "^"
+}
+
+var (
+	builtinTypes      = []string{"S", "N", "B", "D", "E", "REF"}
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	// numberPattern is the decimal literal accepted wherever a number is
+	// written into the generated TypeScript.
+	numberPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+	// reservedNames clash with JavaScript object internals or with members
+	// Mongoose defines on every document and sub-document.
+	reservedNames = []string{
+		"_id", "__v", "__proto__", "constructor", "prototype",
+		"id", "on", "db", "get", "set", "init", "isNew", "errors", "schema", "options",
+		"modelName", "collection", "toObject", "emit", "listeners", "removeListener",
+	}
+	// generatedNames are added to every module by the templates.
+	generatedNames = []string{"creator", "createdAt", "updatedAt"}
+)
+
+// Parse reads a comma separated list of field definitions. Each definition
+// is a name, a type and optional modifiers separated by "@":
+//
+//	title@S@R@max(120)
+//	status@E(active|draft)@default(draft)
+//	tags@S[]
+//	category@REF(Category)@R@index
+//	address{street@S@R,city@S,zip@S}@R
+//	items{product@REF(Product)@R,quantity@N@min(1)}[]
+//
+// Types are S, N, B, D, E(a|b|...) and REF(Model), or one of customTypes
+// (the codes mapped in the project config); a trailing "[]" makes the field
+// a list. A name followed by a braced field list declares a nested object
+// (type O) and takes the place of name@TYPE. Modifiers are R (required),
+// unique, index, default(v), min(n), max(n), len(n) and regex(pattern); a
+// backslash escapes the next character inside parentheses. Whitespace,
+// including newlines, may surround each definition.
+//
+// Malformed definitions, duplicate or reserved names and invalid
+// identifiers are reported as a *SyntaxError pointing at the offending
+// token.
+func Parse(input string, customTypes ...string) ([]types.Field, error) {
+	p := &parser{input: input, types: append(slices.Clone(builtinTypes), customTypes...)}
+	p.skipSpace()
+	if p.eof() {
+		return []types.Field{}, nil
+	}
+	return p.list(true, 0)
+}
+
+type parser struct {
+	input string
+	pos   int
+	types []string
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.input) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+// found describes the input at the current position for error messages.
+func (p *parser) found() string {
+	if p.eof() {
+		return "end of input"
+	}
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return strconv.Quote(string(r))
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) error {
+	lineStart := strings.LastIndexByte(p.input[:pos], '\n') + 1
+	lineEnd := strings.IndexByte(p.input[pos:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(p.input)
+	} else {
+		lineEnd += pos
+	}
+	return &SyntaxError{
+		Line:   strings.Count(p.input[:pos], "\n") + 1,
+		Column: len([]rune(p.input[lineStart:pos])) + 1,
+		Msg:    fmt.Sprintf(format, args...),
+		text:   strings.TrimRight(p.input[lineStart:lineEnd], "\r"),
+	}
+}
+
+// list reads field definitions up to closing, or to the end of the input
+// when closing is 0. The closing character itself is not consumed.
+func (p *parser) list(top bool, closing byte) ([]types.Field, error) {
+	var fields []types.Field
+	for {
+		p.skipSpace()
+		start := p.pos
+		field, err := p.field(top)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(fields, func(f types.Field) bool { return f.Name == field.Name }) {
+			return nil, p.errorf(start, "duplicate field %q", field.Name)
+		}
+		fields = append(fields, field)
+
+		p.skipSpace()
+		switch {
+		case p.peek() == ',':
+			p.pos++
+		case closing != 0 && p.peek() == closing:
+			return fields, nil
+		case closing == 0 && p.eof():
+			return fields, nil
+		case closing != 0 && p.eof():
+			return nil, p.errorf(p.pos, "missing %q", string(closing))
+		default:
+			return nil, p.errorf(p.pos, "expected ',' between fields, found %s", p.found())
+		}
+	}
+}
+
+func (p *parser) field(top bool) (types.Field, error) {
+	start := p.pos
+	name := p.word()
+	if name == "" {
+		return types.Field{}, p.errorf(start, "expected a field name, found %s", p.found())
+	}
+	if err := CheckName(name, top); err != nil {
+		return types.Field{}, p.errorf(start, "%v", err)
+	}
+	field := types.Field{Name: name}
+
+	switch p.peek() {
+	case '{':
+		p.pos++
+		children, err := p.list(false, '}')
+		if err != nil {
+			return types.Field{}, err
+		}
+		p.pos++
+		field.Type = "O"
+		field.Fields = children
+		field.Array = p.listSuffix()
+	case '@':
+		p.pos++
+		if err := p.fieldType(&field); err != nil {
+			return types.Field{}, err
+		}
+	default:
+		return types.Field{}, p.errorf(p.pos, "expected '@' and a type after %q, found %s", name, p.found())
+	}
+
+	var seen []string
+	for p.peek() == '@' {
+		p.pos++
+		modifierStart := p.pos
+		modifier, err := p.modifier(&field)
+		if err != nil {
+			return types.Field{}, err
+		}
+		if slices.Contains(seen, modifier) {
+			return types.Field{}, p.errorf(modifierStart, "modifier %s given twice", modifier)
+		}
+		seen = append(seen, modifier)
+	}
+
+	if err := checkField(field); err != nil {
+		return types.Field{}, p.errorf(start, "field %q: %v", name, err)
+	}
+	return field, nil
+}
+
+func (p *parser) fieldType(field *types.Field) error {
+	start := p.pos
+	code := p.word()
+	if code == "" {
+		return p.errorf(start, "expected a type, found %s", p.found())
+	}
+	if !slices.Contains(p.types, code) {
+		return p.errorf(start, "unknown type %q (expected %s, or name{...} for an object)", code, strings.Join(p.types, ", "))
+	}
+	field.Type = code
+
+	hasArg := p.peek() == '('
+	argStart := p.pos + 1
+	var arg string
+	if hasArg {
+		var err error
+		if arg, err = p.argument(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case code == "E" && hasArg:
+		for _, value := range splitEscaped(arg, '|') {
+			value = unescape(strings.TrimSpace(value))
+			if value == "" {
+				return p.errorf(argStart, "empty enum value")
+			}
+			if slices.Contains(field.Enum, value) {
+				return p.errorf(argStart, "duplicate enum value %q", value)
+			}
+			field.Enum = append(field.Enum, value)
+		}
+	case code == "REF" && hasArg:
+		field.Ref = strings.TrimSpace(arg)
+		if !identifierPattern.MatchString(field.Ref) {
+			return p.errorf(argStart, "REF needs a model name, found %q", field.Ref)
+		}
+	case code == "E" || code == "REF":
+		return p.errorf(p.pos, "type %s needs arguments, e.g. %s(...)", code, code)
+	case hasArg:
+		return p.errorf(argStart-1, "type %s does not take arguments", code)
+	}
+	field.Array = p.listSuffix()
+	return nil
+}
+
+// modifier reads one modifier into field and returns its canonical name.
+func (p *parser) modifier(field *types.Field) (string, error) {
+	start := p.pos
+	name := p.word()
+	if name == "" {
+		return "", p.errorf(start, "expected a modifier, found %s", p.found())
+	}
+	if p.peek() != '(' {
+		switch name {
+		case "R":
+			field.Required = true
+		case "U", "unique":
+			field.Unique = true
+			name = "unique"
+		case "I", "index":
+			field.Index = true
+			name = "index"
+		default:
+			return "", p.errorf(start, "unknown modifier %q (expected R, unique, index, default(), min(), max(), len() or regex())", name)
+		}
+		return name, nil
+	}
+
+	argStart := p.pos + 1
+	arg, err := p.argument()
+	if err != nil {
+		return "", err
+	}
+	if arg == "" {
+		return "", p.errorf(argStart, "%s() needs a value", name)
+	}
+	switch name {
+	case "min", "max", "len":
+		arg = strings.TrimSpace(arg)
+		if !numberPattern.MatchString(arg) {
+			return "", p.errorf(argStart, "%s(%s) is not a number", name, arg)
+		}
+	}
+	switch name {
+	case "default":
+		field.Default = unescape(arg)
+	case "min":
+		field.Min = arg
+	case "max":
+		field.Max = arg
+	case "len":
+		field.Length = arg
+	case "regex":
+		field.Pattern = arg
+	default:
+		return "", p.errorf(start, "unknown modifier %q", name)
+	}
+	return name, nil
+}
+
+// word reads a run of identifier characters.
+func (p *parser) word() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if c != '_' && c != '$' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// argument reads a parenthesised argument and returns its raw text.
+// Parentheses nest, and a backslash escapes the character after it; the
+// backslashes are kept for the caller to remove with unescape, except in
+// regex() where they belong to the pattern.
+func (p *parser) argument() (string, error) {
+	open := p.pos
+	depth := 0
+	for ; !p.eof(); p.pos++ {
+		switch p.peek() {
+		case '\\':
+			p.pos++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.input[open+1 : p.pos-1], nil
+			}
+		}
+	}
+	return "", p.errorf(open, "missing ')' for this '('")
+}
+
+// splitEscaped splits s at each sep that is not escaped by a backslash.
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescape removes the backslashes that escape characters in an argument.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// escape is the inverse of unescape for the characters argument treats
+// specially, plus any in extra.
+func escape(s, extra string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(`\()`+extra, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func (p *parser) listSuffix() bool {
+	if strings.HasPrefix(p.input[p.pos:], "[]") {
+		p.pos += 2
+		return true
+	}
+	return false
+}
+
+// CheckName rejects field names that are not identifiers or that are reserved.
+// top is true for the module's own fields, false inside nested objects.
+func CheckName(name string, top bool) error {
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("%q is not a valid identifier", name)
+	}
+	if slices.Contains(reservedNames, name) {
+		return fmt.Errorf("%q is a reserved name", name)
+	}
+	if top && slices.Contains(generatedNames, name) {
+		return fmt.Errorf("%q is added to every module by the generator", name)
+	}
+	return nil
+}
+
+// checkField rejects modifiers that make no sense for the field's type.
+func checkField(field types.Field) error {
+	for _, bound := range []struct{ name, value string }{{"min", field.Min}, {"max", field.Max}, {"len", field.Length}} {
+		if bound.value == "" {
+			continue
+		}
+		if field.Type != "S" && field.Type != "N" {
+			return fmt.Errorf("%s() only applies to S and N fields", bound.name)
+		}
+		if !numberPattern.MatchString(bound.value) {
+			return fmt.Errorf("%s(%s) is not a number", bound.name, bound.value)
+		}
+		if field.Type == "S" && strings.HasPrefix(bound.value, "-") {
+			return fmt.Errorf("%s(%s) cannot be negative on S fields", bound.name, bound.value)
+		}
+	}
+	if field.Length != "" && field.Type != "S" {
+		return fmt.Errorf("len() only applies to S fields")
+	}
+	if field.Length != "" && (field.Min != "" || field.Max != "") {
+		return fmt.Errorf("len() cannot be combined with min() or max()")
+	}
+	if field.Min != "" && field.Max != "" {
+		min, _ := strconv.ParseFloat(field.Min, 64)
+		max, _ := strconv.ParseFloat(field.Max, 64)
+		if min > max {
+			return fmt.Errorf("min(%s) is greater than max(%s)", field.Min, field.Max)
+		}
+	}
+	if field.Pattern != "" && field.Type != "S" {
+		return fmt.Errorf("regex() only applies to S fields")
+	}
+	if field.Type == "O" && (field.Unique || field.Index || field.Default != "") {
+		return fmt.Errorf("objects only take the R modifier")
+	}
+
+	if field.Default == "" {
+		return nil
+	}
+	if field.Array {
+		return fmt.Errorf("default() is not supported on lists")
+	}
+	switch field.Type {
+	case "N":
+		if !numberPattern.MatchString(field.Default) {
+			return fmt.Errorf("default(%s) is not a number", field.Default)
+		}
+	case "D":
+		if !isDate(field.Default) {
+			return fmt.Errorf("default(%s) must be now or a date such as 2024-01-31 or 2024-01-31T09:00:00Z", field.Default)
+		}
+	case "B":
+		if field.Default != "true" && field.Default != "false" {
+			return fmt.Errorf("default(%s) must be true or false", field.Default)
+		}
+	case "E":
+		if !slices.Contains(field.Enum, field.Default) {
+			return fmt.Errorf("default(%s) is not one of the enum values", field.Default)
+		}
+	case "REF":
+		return fmt.Errorf("default() is not supported on REF fields")
+	}
+	return nil
+}
+
+// isDate reports whether value is a date default: "now", a calendar date or
+// an RFC 3339 timestamp.
+func isDate(value string) bool {
+	if value == "now" {
+		return true
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
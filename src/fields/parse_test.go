@@ -0,0 +1,181 @@
+package fields
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // Format of the parsed fields
+	}{
+		{"", ""},
+		{"name@S@R,age@N@min(0)", "name@S@R,age@N@min(0)"},
+		{" name@S@R ,\n\tage@N ", "name@S@R,age@N"},
+		{"status@E(a|b|c)@default(a)", "status@E(a|b|c)@default(a)"},
+		{"owner@REF(User)@I", "owner@REF(User)@index"},
+		{"email@S@U", "email@S@unique"},
+		{"score@N@min(-1.5)@max(10)@default(0)", "score@N@default(0)@min(-1.5)@max(10)"},
+		{"due@D@default(now)", "due@D@default(now)"},
+		{"due@D@default(2024-01-31)", "due@D@default(2024-01-31)"},
+		{"due@D@default(2024-01-31T09:00:00Z)", "due@D@default(2024-01-31T09:00:00Z)"},
+		{"address{city@S@R,zip@N}", "address{city@S@R,zip@N}"},
+		{"tags@S[]", "tags@S[]"},
+		{"items{sku@S,qty@N}[]", "items{sku@S,qty@N}[]"},
+		{`slug@S@regex(^[a-z\)]+$)`, `slug@S@regex(^[a-z\)]+$)`},
+	}
+	for _, tt := range tests {
+		fields, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if got := Format(fields); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+		msg          string
+	}{
+		{"@S", 1, 1, `expected a field name, found "@"`},
+		{"name@S,", 1, 8, "expected a field name, found end of input"},
+		{"name@S,name@N", 1, 8, `duplicate field "name"`},
+		{"name", 1, 5, `expected '@' and a type after "name", found end of input`},
+		{"name@X", 1, 6, `unknown type "X" (expected S, N, B, D, E, REF, or name{...} for an object)`},
+		{"name@S(x)", 1, 7, "type S does not take arguments"},
+		{"status@E", 1, 9, "type E needs arguments, e.g. E(...)"},
+		{"status@E(a||b)", 1, 10, "empty enum value"},
+		{"status@E(a|a)", 1, 10, `duplicate enum value "a"`},
+		{"owner@REF(1x)", 1, 11, `REF needs a model name, found "1x"`},
+		{"name@S@foo", 1, 8, `unknown modifier "foo" (expected R, unique, index, default(), min(), max(), len() or regex())`},
+		{"name@S@R@R", 1, 10, "modifier R given twice"},
+		{"age@N@min()", 1, 11, "min() needs a value"},
+		{"age@N@min(abc)", 1, 11, "min(abc) is not a number"},
+		{"qty@N@max(inf)", 1, 11, "max(inf) is not a number"},
+		{"qty@N@min(NaN)", 1, 11, "min(NaN) is not a number"},
+		{"qty@N@max(Infinity)", 1, 11, "max(Infinity) is not a number"},
+		{"qty@N@max(0x1p3)", 1, 11, "max(0x1p3) is not a number"},
+		{"qty@N@min(1e3)", 1, 11, "min(1e3) is not a number"},
+		{"qty@N@default(nan)", 1, 1, `field "qty": default(nan) is not a number`},
+		{"qty@N@default(.5)", 1, 1, `field "qty": default(.5) is not a number`},
+		{"code@S@min(-1)", 1, 1, `field "code": min(-1) cannot be negative on S fields`},
+		{"code@S@len(-2)", 1, 1, `field "code": len(-2) cannot be negative on S fields`},
+		{"due@D@default(tomorrow)", 1, 1, `field "due": default(tomorrow) must be now or a date such as 2024-01-31 or 2024-01-31T09:00:00Z`},
+		{"name@S@default(x", 1, 15, "missing ')' for this '('"},
+		{"name@S age@N", 1, 8, `expected ',' between fields, found "a"`},
+		{"address{city@S", 1, 15, `missing "}"`},
+		{"name@S,\n  age@N@min(x)", 2, 13, "min(x) is not a number"},
+		{"_id@S", 1, 1, `"_id" is a reserved name`},
+		{"createdAt@D", 1, 1, `"createdAt" is added to every module by the generator`},
+		{"1name@S", 1, 1, `"1name" is not a valid identifier`},
+		{"naïve@S", 1, 3, `expected '@' and a type after "na", found "ï"`},
+		{"a@S@default(é),a@N", 1, 16, `duplicate field "a"`},
+		// Malformed input that once ran past the end of the string.
+		{"a{b{c{", 1, 7, "expected a field name, found end of input"},
+		{"x@E(", 1, 4, "missing ')' for this '('"},
+		{`x@S@regex(\`, 1, 10, "missing ')' for this '('"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) error = %v, want a *SyntaxError", tt.input, err)
+			continue
+		}
+		if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column || syntaxErr.Msg != tt.msg {
+			t.Errorf("Parse(%q) error = %d:%d %q, want %d:%d %q", tt.input,
+				syntaxErr.Line, syntaxErr.Column, syntaxErr.Msg, tt.line, tt.column, tt.msg)
+		}
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // error text, or "" when valid
+	}{
+		{"code@S@len(5)", ""},
+		{"code@S@min(2)@max(5)", ""},
+		{"n@N@min(2)@max(2)", ""},
+		{"code@S@len(5)@min(2)", "len() cannot be combined with min() or max()"},
+		{"code@S@max(9)@len(5)", "len() cannot be combined with min() or max()"},
+		{"n@N@min(5)@max(2)", "min(5) is greater than max(2)"},
+		{"n@N@min(-1)@max(-3)", "min(-1) is greater than max(-3)"},
+		{"n@N@min(-3)@max(-1)", ""},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Parse(%q): %v", tt.input, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Parse(%q) error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"name@S@R,age@N@min(0)@max(120)",
+		"status@E(a|b|c)@default(a)",
+		"owner@REF(User)@index",
+		"items{sku@S@unique,qty@N}[]",
+		`slug@S@regex(^[a-z\\)]+$)`,
+		"a{b{c{",
+		"x@E(",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		fields, err := Parse(input)
+		if err != nil {
+			return
+		}
+		formatted := Format(fields)
+		again, err := Parse(formatted)
+		if err != nil {
+			t.Fatalf("Parse(%q) accepted, but its Format %q fails: %v", input, formatted, err)
+		}
+		if got := Format(again); got != formatted {
+			t.Fatalf("Format round trip of %q: %q, then %q", input, formatted, got)
+		}
+	})
+}
+
+func TestParseEscapes(t *testing.T) {
+	tests := []struct {
+		input   string
+		check   func(types.Field) string
+		want    string
+		written string // Format of the parsed field
+	}{
+		{`note@S@default(x\)y)`, func(f types.Field) string { return f.Default }, "x)y", `note@S@default(x\)y)`},
+		{`note@S@default(a\\b)`, func(f types.Field) string { return f.Default }, `a\b`, `note@S@default(a\\b)`},
+		{`note@S@default(f(x))`, func(f types.Field) string { return f.Default }, "f(x)", `note@S@default(f\(x\))`},
+		{`size@E(s\)|m|l)`, func(f types.Field) string { return strings.Join(f.Enum, ",") }, "s),m,l", `size@E(s\)|m|l)`},
+		{`size@E(a\|b|c)`, func(f types.Field) string { return strings.Join(f.Enum, ",") }, "a|b,c", ""},
+		{`slug@S@regex(^\(\d+\)$)`, func(f types.Field) string { return f.Pattern }, `^\(\d+\)$`, `slug@S@regex(^\(\d+\)$)`},
+	}
+	for _, tt := range tests {
+		fields, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if got := tt.check(fields[0]); got != tt.want {
+			t.Errorf("Parse(%q) stored %q, want %q", tt.input, got, tt.want)
+		}
+		if got := Format(fields); tt.written != "" && got != tt.written {
+			t.Errorf("Format(Parse(%q)) = %q, want %q", tt.input, got, tt.written)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package fields
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+	"gopkg.in/yaml.v3"
+)
+
+var objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+// FromSample infers fields from an example JSON payload: one object, or an
+// array of objects that are merged. A field is required when every sample
+// has it with a non-null value. Strings holding dates become D, strings
+// holding ObjectIds become REF fields, nested objects become O and arrays
+// become lists. Values the generator cannot express are skipped and
+// reported in the returned warnings.
+func FromSample(data []byte) ([]types.Field, []string, error) {
+	if !json.Valid(data) {
+		var v any
+		err := json.Unmarshal(data, &v)
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	// JSON is YAML; decoding to nodes keeps the keys in document order.
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, err
+	}
+	root := document.Content[0]
+
+	var samples []*yaml.Node
+	switch root.Kind {
+	case yaml.MappingNode:
+		samples = []*yaml.Node{root}
+	case yaml.SequenceNode:
+		for i, item := range root.Content {
+			if item.Kind != yaml.MappingNode {
+				return nil, nil, fmt.Errorf("item %d of the sample array is not an object", i)
+			}
+		}
+		samples = root.Content
+	}
+	if len(samples) == 0 {
+		return nil, nil, fmt.Errorf("expected a JSON object or a non-empty array of objects")
+	}
+
+	r := &sampleReader{}
+	fields := r.object(samples, "", true)
+	if len(fields) == 0 {
+		return nil, r.warnings, fmt.Errorf("the sample has no fields the generator can use")
+	}
+	return fields, r.warnings, nil
+}
+
+type sampleReader struct {
+	warnings []string
+}
+
+func (r *sampleReader) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// object merges the keys of several sample objects, in order of first
+// appearance.
+func (r *sampleReader) object(samples []*yaml.Node, path string, top bool) []types.Field {
+	var names []string
+	values := map[string][]*yaml.Node{}
+	for _, sample := range samples {
+		for i := 0; i+1 < len(sample.Content); i += 2 {
+			name := sample.Content[i].Value
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+			values[name] = append(values[name], sample.Content[i+1])
+		}
+	}
+
+	var fields []types.Field
+	for _, name := range names {
+		if err := CheckName(name, top); err != nil {
+			r.warnf("skipped %s%s: %v", path, name, err)
+			continue
+		}
+		present := slices.DeleteFunc(values[name], isNull)
+		field, ok := r.field(name, path+name, present)
+		if !ok {
+			continue
+		}
+		field.Required = !field.Array && len(present) == len(samples)
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// field infers one field from the non-null values seen for it.
+func (r *sampleReader) field(name, path string, values []*yaml.Node) (types.Field, bool) {
+	if len(values) == 0 {
+		r.warnf("skipped %s: only null values in the sample", path)
+		return types.Field{}, false
+	}
+	kind := values[0].Kind
+	for _, v := range values {
+		if v.Kind != kind {
+			r.warnf("skipped %s: mixes objects, arrays and plain values", path)
+			return types.Field{}, false
+		}
+	}
+
+	switch kind {
+	case yaml.MappingNode:
+		children := r.object(values, path+".", false)
+		if len(children) == 0 {
+			r.warnf("skipped %s: no usable fields", path)
+			return types.Field{}, false
+		}
+		return types.Field{Name: name, Type: "O", Fields: children}, true
+	case yaml.SequenceNode:
+		var items []*yaml.Node
+		for _, v := range values {
+			items = append(items, slices.DeleteFunc(slices.Clone(v.Content), isNull)...)
+		}
+		if len(items) == 0 {
+			r.warnf("skipped %s: only empty arrays in the sample", path)
+			return types.Field{}, false
+		}
+		field, ok := r.field(name, path, items)
+		if ok && field.Array {
+			r.warnf("skipped %s: nested arrays are not supported", path)
+			return types.Field{}, false
+		}
+		field.Array = true
+		return field, ok
+	}
+
+	code := ""
+	for _, v := range values {
+		next := scalarType(v)
+		switch {
+		case code == "" || code == next:
+			code = next
+		case (code == "S" || code == "D" || code == "REF") && (next == "S" || next == "D" || next == "REF"):
+			code = "S"
+		default:
+			r.warnf("skipped %s: mixes %s and %s values", path, sampleTypeNames[code], sampleTypeNames[next])
+			return types.Field{}, false
+		}
+	}
+	field := types.Field{Name: name, Type: code}
+	if code == "REF" {
+		field.Ref = toPascal(strings.TrimSuffix(strings.TrimSuffix(name, "Id"), "_id"))
+		r.warnf("%s: ObjectId values, ref assumed to be %s", path, field.Ref)
+	}
+	return field, true
+}
+
+var sampleTypeNames = map[string]string{"S": "string", "N": "number", "B": "boolean", "D": "date", "REF": "ObjectId"}
+
+// scalarType returns the field type a JSON scalar suggests.
+func scalarType(v *yaml.Node) string {
+	switch v.Tag {
+	case "!!int", "!!float":
+		return "N"
+	case "!!bool":
+		return "B"
+	}
+	if objectIDPattern.MatchString(v.Value) {
+		return "REF"
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if _, err := time.Parse(layout, v.Value); err == nil {
+			return "D"
+		}
+	}
+	return "S"
+}
@@ -0,0 +1,75 @@
+package fields
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+var schemaPattern = regexp.MustCompile(`new\s+(?:\w+\.)*Schema\s*[<(]`)
+
+// FromSource reads fields from an existing model file: a Mongoose schema
+// when src constructs one, otherwise a TypeScript interface.
+func FromSource(src string) ([]types.Field, []string, error) {
+	if schemaPattern.MatchString(src) {
+		return FromMongoose(src)
+	}
+	return FromTypeScript(src)
+}
+
+// Format writes fields back in the syntax Parse reads.
+func Format(fields []types.Field) string {
+	definitions := make([]string, len(fields))
+	for i, f := range fields {
+		definitions[i] = formatField(f)
+	}
+	return strings.Join(definitions, ",")
+}
+
+func formatField(f types.Field) string {
+	var b strings.Builder
+	b.WriteString(f.Name)
+	switch f.Type {
+	case "O":
+		b.WriteString("{" + Format(f.Fields) + "}")
+	case "E":
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = escape(value, "|")
+		}
+		b.WriteString("@E(" + strings.Join(values, "|") + ")")
+	case "REF":
+		b.WriteString("@REF(" + f.Ref + ")")
+	default:
+		b.WriteString("@" + f.Type)
+	}
+	if f.Array {
+		b.WriteString("[]")
+	}
+	if f.Required {
+		b.WriteString("@R")
+	}
+	if f.Unique {
+		b.WriteString("@unique")
+	}
+	if f.Index {
+		b.WriteString("@index")
+	}
+	for _, modifier := range []struct{ name, value string }{
+		{"default", escape(f.Default, "")}, {"min", f.Min}, {"max", f.Max}, {"len", f.Length}, {"regex", f.Pattern},
+	} {
+		if modifier.value != "" {
+			fmt.Fprintf(&b, "@%s(%s)", modifier.name, modifier.value)
+		}
+	}
+	return b.String()
+}
+
+// Check applies the rules Parse enforces to fields built by other means,
+// such as the readers of existing model files.
+func Check(fields []types.Field, customTypes ...string) error {
+	_, err := Parse(Format(fields), customTypes...)
+	return err
+}
@@ -0,0 +1,99 @@
+package fields
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFromSource(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "mongoose schema",
+			src: `import { Schema, model } from "mongoose";
+const OrderSchema = new Schema({
+  title: { type: String, required: true, maxlength: 120 },
+  qty: { type: Number, min: 1 },
+  status: { type: String, enum: ["open", "closed"], default: "open" },
+  tags: [String],
+  customer: { type: Schema.Types.ObjectId, ref: "Customer" },
+});
+export default model("Order", OrderSchema);
+`,
+			want: "title@S@R@max(120),qty@N@min(1),status@E(open|closed)@default(open),tags@S[],customer@REF(Customer)",
+		},
+		{
+			name: "typescript interface",
+			src: `export interface IOrder {
+  title: string;
+  qty?: number;
+  status: "open" | "closed";
+  tags: string[];
+  address: { city: string; zip?: string };
+}
+`,
+			want: "title@S@R,qty@N,status@E(open|closed)@R,tags@S[]@R,address{city@S@R,zip@S}@R",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, _, err := FromSource(tt.src)
+			if err != nil {
+				t.Fatalf("FromSource: %v", err)
+			}
+			if got := Format(fields); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Malformed input used to leave the readers looping on a token they
+// could not consume.
+func TestFromSourceMalformed(t *testing.T) {
+	for _, src := range []string{
+		`const S = new Schema({ b: [Number;] });`,
+		`const S = new Schema({ b: { type: String, enum: Array(;) } });`,
+		`interface I { a: string; b?): number[]; }`,
+		`interface I { a: Array<;>; }`,
+		`interface I { ) }`,
+	} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			FromSource(src)
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("FromSource(%q) did not return", src)
+		}
+	}
+}
+
+// Enum values are checked by formatting them back into the field syntax,
+// so the characters that syntax treats specially must survive the trip.
+func TestCheckEnumValues(t *testing.T) {
+	src := `const S = new Schema({
+  size: { type: String, enum: ["s|m", "l,xl", "(x)", "a\\b"], default: "s|m" },
+});`
+	fields, _, err := FromSource(src)
+	if err != nil {
+		t.Fatalf("FromSource: %v", err)
+	}
+	if err := Check(fields); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	again, err := Parse(Format(fields))
+	if err != nil {
+		t.Fatalf("Parse(Format): %v", err)
+	}
+	want := []string{"s|m", "l,xl", "(x)", `a\b`}
+	if !slices.Equal(again[0].Enum, want) || again[0].Default != "s|m" {
+		t.Errorf("round trip gave enum %q default %q, want %q default %q", again[0].Enum, again[0].Default, want, "s|m")
+	}
+}
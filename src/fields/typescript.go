@@ -0,0 +1,315 @@
+package fields
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/tslex"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// typeExpr is a TypeScript type as far as field declarations need it.
+type typeExpr struct {
+	name    string      // type name, or the value of a literal type
+	literal bool        // a string or number literal type
+	members []member    // properties of an object type
+	union   []*typeExpr // alternatives of a union type
+	array   int         // number of [] suffixes
+}
+
+type member struct {
+	name     string
+	optional bool
+	typ      *typeExpr
+}
+
+func parseUnion(s *tslex.Stream) *typeExpr {
+	s.Accept("|")
+	options := []*typeExpr{parsePrimaryType(s)}
+	for s.Accept("|") {
+		options = append(options, parsePrimaryType(s))
+	}
+	if len(options) == 1 {
+		return options[0]
+	}
+	return &typeExpr{union: options}
+}
+
+func parsePrimaryType(s *tslex.Stream) *typeExpr {
+	var t *typeExpr
+	s.Accept("readonly")
+	switch tok := s.Peek(); {
+	case s.Is("("):
+		s.Next()
+		t = parseUnion(s)
+		s.Accept(")")
+	case s.Is("{"):
+		t = &typeExpr{members: parseMembers(s)}
+	case tok.Kind == tslex.String || tok.Kind == tslex.Number:
+		s.Next()
+		t = &typeExpr{name: tok.Text, literal: true}
+	case tok.Kind == tslex.Ident:
+		t = &typeExpr{name: dottedName(s)}
+		if s.Is("<") {
+			s.Next()
+			var args []*typeExpr
+			for !s.Is(">") && s.Peek().Kind != tslex.EOF {
+				start := s.Index()
+				args = append(args, parseUnion(s))
+				s.Accept(",")
+				skipIfStuck(s, start)
+			}
+			s.Accept(">")
+			if (t.name == "Array" || t.name == "ReadonlyArray") && len(args) == 1 {
+				t = args[0]
+				t.array++
+			}
+		}
+	default:
+		s.SkipType()
+		return &typeExpr{name: "unknown"}
+	}
+	for s.Is("[") && s.PeekAt(1).Text == "]" {
+		s.Next()
+		s.Next()
+		t.array++
+	}
+	return t
+}
+
+// parseMembers reads the properties of an object type or interface body,
+// skipping methods and index signatures.
+func parseMembers(s *tslex.Stream) []member {
+	var members []member
+	s.Accept("{")
+	for !s.Is("}") && s.Peek().Kind != tslex.EOF {
+		start := s.Index()
+		s.Accept("readonly")
+		key := s.Peek()
+		if key.Kind != tslex.Ident && key.Kind != tslex.String {
+			s.SkipType()
+			s.Accept(";")
+			s.Accept(",")
+			skipIfStuck(s, start)
+			continue
+		}
+		s.Next()
+		optional := s.Accept("?")
+		if !s.Accept(":") {
+			s.SkipType()
+		} else {
+			members = append(members, member{name: key.Text, optional: optional, typ: parseUnion(s)})
+		}
+		if !s.Accept(";") && !s.Accept(",") && !s.Is("}") {
+			s.SkipType()
+			s.Accept(";")
+		}
+		skipIfStuck(s, start)
+	}
+	s.Accept("}")
+	return members
+}
+
+// FromTypeScript reads the fields of the interface (or object type alias)
+// declared in src. With several declarations, the first one not used by
+// another is taken as the module; the others become nested objects where
+// they are referenced. Types the generator cannot express are skipped and
+// reported in the returned warnings.
+func FromTypeScript(src string) ([]types.Field, []string, error) {
+	tokens, err := tslex.Lex(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := tslex.NewStream(tokens)
+	declared := map[string][]member{}
+	var order []string
+	for s.Peek().Kind != tslex.EOF {
+		t := s.Next()
+		if t.Kind != tslex.Ident || (t.Text != "interface" && t.Text != "type") || s.Peek().Kind != tslex.Ident {
+			continue
+		}
+		name := s.Next().Text
+		if s.Is("<") {
+			s.SkipGroup("<", ">")
+		}
+		var members []member
+		if t.Text == "interface" {
+			if s.Accept("extends") {
+				for !s.Is("{") && s.Peek().Kind != tslex.EOF {
+					if base := s.Next(); base.Kind == tslex.Ident {
+						members = append(members, declared[base.Text]...)
+					}
+				}
+			}
+		} else if !s.Accept("=") || !s.Is("{") {
+			continue
+		}
+		if !s.Is("{") {
+			continue
+		}
+		declared[name] = append(members, parseMembers(s)...)
+		order = append(order, name)
+	}
+	if len(order) == 0 {
+		return nil, nil, fmt.Errorf("no interface or object type declaration found")
+	}
+
+	used := map[string]bool{}
+	for _, members := range declared {
+		for _, m := range members {
+			markUsed(m.typ, used)
+		}
+	}
+	root := order[0]
+	for _, name := range order {
+		if !used[name] {
+			root = name
+			break
+		}
+	}
+
+	r := &typeReader{declared: declared}
+	fields := r.members(declared[root], "", true)
+	if len(fields) == 0 {
+		return nil, r.warnings, fmt.Errorf("%s has no fields the generator can use", root)
+	}
+	return fields, r.warnings, nil
+}
+
+func markUsed(t *typeExpr, used map[string]bool) {
+	used[t.name] = true
+	for _, option := range t.union {
+		markUsed(option, used)
+	}
+	for _, m := range t.members {
+		markUsed(m.typ, used)
+	}
+}
+
+type typeReader struct {
+	declared map[string][]member
+	visiting []string
+	warnings []string
+}
+
+func (r *typeReader) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *typeReader) members(members []member, path string, top bool) []types.Field {
+	var fields []types.Field
+	for _, m := range members {
+		if err := CheckName(m.name, top); err != nil {
+			r.warnf("skipped %s%s: %v", path, m.name, err)
+			continue
+		}
+		field, ok := r.field(m.name, path+m.name, m.typ)
+		if !ok {
+			continue
+		}
+		field.Required = field.Required && !m.optional
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+var tsTypes = map[string]string{
+	"string":   "S",
+	"String":   "S",
+	"number":   "N",
+	"Number":   "N",
+	"boolean":  "B",
+	"Boolean":  "B",
+	"Date":     "D",
+	"ObjectId": "REF",
+}
+
+// field converts a property type. The result is required unless the type
+// includes null or undefined.
+func (r *typeReader) field(name, path string, t *typeExpr) (types.Field, bool) {
+	if t.array > 1 {
+		r.warnf("skipped %s: nested arrays are not supported", path)
+		return types.Field{}, false
+	}
+	field := types.Field{Name: name, Required: true, Array: t.array == 1}
+
+	if t.union != nil {
+		var options []*typeExpr
+		for _, option := range t.union {
+			if option.name == "null" || option.name == "undefined" {
+				field.Required = false
+			} else {
+				options = append(options, option)
+			}
+		}
+		switch {
+		case len(options) == 1:
+			inner, ok := r.field(name, path, options[0])
+			inner.Required = inner.Required && field.Required
+			inner.Array = inner.Array || field.Array
+			return inner, ok
+		case !slices.ContainsFunc(options, func(o *typeExpr) bool { return !o.literal || o.array > 0 }):
+			field.Type = "E"
+			for _, option := range options {
+				field.Enum = append(field.Enum, option.name)
+			}
+			return field, true
+		}
+		// Populated references: Types.ObjectId | IUser
+		for _, option := range options {
+			if tsTypes[lastSegment(option.name)] == "REF" {
+				field.Type = "REF"
+			} else if _, ok := r.declared[option.name]; ok {
+				field.Ref = strings.TrimPrefix(option.name, "I")
+			}
+		}
+		if field.Type == "REF" {
+			if field.Ref == "" {
+				field.Ref = toPascal(name)
+				r.warnf("%s: ref assumed to be %s", path, field.Ref)
+			}
+			return field, true
+		}
+		r.warnf("skipped %s: unsupported union type", path)
+		return types.Field{}, false
+	}
+
+	switch {
+	case t.members != nil:
+		return r.object(field, path, t.members)
+	case t.literal:
+		field.Type = "E"
+		field.Enum = []string{t.name}
+		return field, true
+	}
+	if members, ok := r.declared[t.name]; ok {
+		if slices.Contains(r.visiting, t.name) {
+			r.warnf("skipped %s: recursive type %s", path, t.name)
+			return types.Field{}, false
+		}
+		r.visiting = append(r.visiting, t.name)
+		defer func() { r.visiting = r.visiting[:len(r.visiting)-1] }()
+		return r.object(field, path, members)
+	}
+	field.Type = tsTypes[lastSegment(t.name)]
+	switch field.Type {
+	case "":
+		r.warnf("skipped %s: unsupported type %s", path, t.name)
+		return types.Field{}, false
+	case "REF":
+		field.Ref = toPascal(name)
+		r.warnf("%s: ref assumed to be %s", path, field.Ref)
+	}
+	return field, true
+}
+
+func (r *typeReader) object(field types.Field, path string, members []member) (types.Field, bool) {
+	field.Type = "O"
+	field.Fields = r.members(members, path+".", false)
+	if len(field.Fields) == 0 {
+		r.warnf("skipped %s: no usable fields", path)
+		return types.Field{}, false
+	}
+	return field, true
+}
@@ -0,0 +1,11 @@
+package frontendmodule
+
+import (
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// GetCreateFrontendModuleInstructions returns the instructions for a React
+// frontend module, read from templates/fm and from overrideDir if it exists.
+func GetCreateFrontendModuleInstructions(overrideDir string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
+	return loadTemplateSet("fm", overrideDir)
+}
@@ -0,0 +1,11 @@
+package frontendmodule
+
+import (
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// GetInitProjectInstructions returns the instructions for a new Vite + React
+// project, read from templates/fp and from overrideDir if it exists.
+func GetInitProjectInstructions(overrideDir string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
+	return loadTemplateSet("fp", overrideDir)
+}
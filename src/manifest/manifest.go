@@ -0,0 +1,163 @@
+// Package manifest keeps .super/manifest.json, the record of what the
+// generator wrote into a project: each module's definition, the templates
+// it was rendered from, and the files and insertions it produced.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sohel902833/go_super_cli/src/config"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// FileName is the manifest kept in the project's .super directory.
+const FileName = "manifest.json"
+
+// Version is the manifest format written by this CLI.
+const Version = 1
+
+type Manifest struct {
+	Version int      `json:"version"`
+	Project *Entry   `json:"project,omitempty"`
+	Modules []*Entry `json:"modules"`
+}
+
+// Entry records one generation: the project itself or a module.
+type Entry struct {
+	Name        string                  `json:"name"`
+	Type        string                  `json:"type"` // bm, fm, bp or fp
+	Fields      []types.FieldDefinition `json:"fields,omitempty"`
+	Templates   Templates               `json:"templates"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Files       []File                  `json:"files"`
+	Updates     []Update                `json:"updates,omitempty"`
+}
+
+// Templates names the template set an entry was rendered from.
+type Templates struct {
+	Set     string `json:"set"`     // embedded set such as "bm", or the config name
+	Version string `json:"version"` // digest of the set's instructions
+}
+
+// File is a file the generator wrote, with the hash of what it wrote.
+type File struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+// Update is a snippet the generator put into a shared file, in the marker
+// block named Block.
+type Update struct {
+	Path  string `json:"path"`
+	Block string `json:"block"`
+	Hash  string `json:"hash"`
+}
+
+// Path returns the manifest's location in the project at root.
+func Path(root string) string {
+	return filepath.Join(root, config.DirName, FileName)
+}
+
+// Load reads the manifest of the project at root. A project without one
+// gets an empty manifest.
+func Load(root string) (*Manifest, error) {
+	data, err := os.ReadFile(Path(root))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{Version: Version}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", Path(root), err)
+	}
+	if m.Version > Version {
+		return nil, fmt.Errorf("%s has version %d; this CLI understands up to %d", Path(root), m.Version, Version)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest into the project at root.
+func (m *Manifest) Save(root string) error {
+	m.Version = Version
+	if m.Modules == nil {
+		m.Modules = []*Entry{}
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(Path(root)), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(root), append(data, '\n'), 0644)
+}
+
+// Module returns the entry of the module named name, or nil.
+func (m *Manifest) Module(name string) *Entry {
+	for _, entry := range m.Modules {
+		if strings.EqualFold(entry.Name, name) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// SetModule records entry, replacing an earlier entry for the module.
+func (m *Manifest) SetModule(entry *Entry) {
+	for i, existing := range m.Modules {
+		if strings.EqualFold(existing.Name, entry.Name) {
+			m.Modules[i] = entry
+			return
+		}
+	}
+	m.Modules = append(m.Modules, entry)
+}
+
+// RemoveModule drops the entry of the module named name.
+func (m *Manifest) RemoveModule(name string) {
+	for i, entry := range m.Modules {
+		if strings.EqualFold(entry.Name, name) {
+			m.Modules = append(m.Modules[:i], m.Modules[i+1:]...)
+			return
+		}
+	}
+}
+
+// File returns the recorded file at path, or nil. A nil entry has none.
+func (e *Entry) File(path string) *File {
+	if e == nil {
+		return nil
+	}
+	for i := range e.Files {
+		if e.Files[i].Path == path {
+			return &e.Files[i]
+		}
+	}
+	return nil
+}
+
+// Hash returns the content hash recorded for a file or snippet.
+func Hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// TemplateVersion returns a digest of a template set, which changes
+// whenever any of its templates does.
+func TemplateVersion(instructions []types.FileInstruction, updates []types.UpdateInstruction) string {
+	data, _ := json.Marshal(struct {
+		Files   []types.FileInstruction
+		Updates []types.UpdateInstruction
+	}{instructions, updates})
+	return Hash(string(data))[:len("sha256:")+12]
+}
@@ -0,0 +1,279 @@
+// Package markers splices generated snippets into existing files inside
+// named marker comments, so they can be replaced or removed later:
+//
+//	// super:begin route:order
+//	{ path: "/order", route: orderRoutes },
+//	// super:end
+package markers
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	beginTag = "super:begin"
+	endTag   = "super:end"
+)
+
+// AnchorError is returned by Apply when the file has neither the block nor
+// an anchor line to put it next to.
+type AnchorError struct {
+	Anchor string
+	Inline bool // the anchor is there, but shares its line with other text
+}
+
+func (e *AnchorError) Error() string {
+	if e.Inline {
+		return fmt.Sprintf("placeholder '%s' must be on a line of its own", e.Anchor)
+	}
+	return fmt.Sprintf("placeholder '%s' not found in file", e.Anchor)
+}
+
+// Block is a marker block found in a file. Start and End are byte offsets
+// spanning the begin line through the end line and its newline.
+type Block struct {
+	ID     string
+	Start  int
+	End    int
+	Indent string
+	open   string
+	close  string
+}
+
+// Find returns the blocks named id, or every block when id is empty, in
+// file order.
+func Find(file, id string) ([]Block, error) {
+	var blocks []Block
+	var current *Block
+	offset := 0
+	for line := range strings.SplitAfterSeq(file, "\n") {
+		start := offset
+		offset += len(line)
+		text := strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimSpace(text)
+
+		if current != nil {
+			if strings.Contains(trimmed, endTag) && !strings.Contains(trimmed, beginTag) {
+				current.End = offset
+				blocks = append(blocks, *current)
+				current = nil
+			} else if strings.Contains(trimmed, beginTag) {
+				return nil, fmt.Errorf("block %s is not closed with %s", current.ID, endTag)
+			}
+			continue
+		}
+
+		i := strings.Index(trimmed, beginTag+" ")
+		if i < 0 {
+			continue
+		}
+		rest := trimmed[i+len(beginTag)+1:]
+		name, after, _ := strings.Cut(strings.TrimLeft(rest, " "), " ")
+		if id != "" && name != id {
+			continue
+		}
+		current = &Block{
+			ID:     name,
+			Start:  start,
+			Indent: text[:len(text)-len(strings.TrimLeft(text, " \t"))],
+			open:   strings.TrimSpace(trimmed[:i]),
+			close:  strings.TrimSpace(after),
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("block %s is not closed with %s", current.ID, endTag)
+	}
+	return blocks, nil
+}
+
+// Apply puts content into the block named id. Existing blocks are replaced
+// in place; otherwise a new block goes above (position "top", the default)
+// or below ("bottom") every line consisting of the anchor. A copy of
+// content that an older version inserted without markers is wrapped in
+// place instead of being added again. changed is false when the file
+// already holds exactly this block.
+func Apply(file, anchor, id, content, position string) (string, bool, error) {
+	blocks, err := Find(file, id)
+	if err != nil {
+		return "", false, err
+	}
+	result := file
+	if len(blocks) > 0 {
+		for i := len(blocks) - 1; i >= 0; i-- {
+			b := blocks[i]
+			result = result[:b.Start] + wrap(b.open, b.close, id, content, b.Indent) + result[b.End:]
+		}
+		return result, result != file, nil
+	}
+
+	anchors := anchorLines(file, anchor)
+	if len(anchors) == 0 {
+		return "", false, &AnchorError{Anchor: anchor, Inline: strings.Contains(file, anchor)}
+	}
+	open, close := commentStyle(anchor)
+
+	if adopted, ok := adopt(file, open, close, id, content, anchors[0].indent); ok {
+		return adopted, true, nil
+	}
+
+	for i := len(anchors) - 1; i >= 0; i-- {
+		a := anchors[i]
+		block := wrap(open, close, id, content, a.indent)
+		if position != "bottom" {
+			result = result[:a.start] + block + result[a.start:]
+			continue
+		}
+		if !strings.HasSuffix(result[:a.end], "\n") {
+			block = "\n" + block
+		}
+		result = result[:a.end] + block + result[a.end:]
+	}
+	return result, true, nil
+}
+
+// Insert puts content in a new "//" block named id before the line starting
+// at offset at, or after the block at falls in, indented by indent, unless
+// an unmarked copy is adopted as in Apply. Blocks named id that already
+// exist are replaced instead.
+func Insert(file string, at int, indent, id, content string) (string, bool, error) {
+	blocks, err := Find(file, "")
+	if err != nil {
+		return "", false, err
+	}
+	for _, b := range blocks {
+		if b.ID == id {
+			return Apply(file, "", id, content, "")
+		}
+		if b.Start < at && at < b.End {
+			at = b.End
+		}
+	}
+	if adopted, ok := adopt(file, "//", "", id, content, indent); ok {
+		return adopted, true, nil
+	}
+	block := wrap("//", "", id, content, indent)
+	if at > 0 && file[at-1] != '\n' {
+		block = "\n" + block
+	}
+	return file[:at] + block + file[at:], true, nil
+}
+
+// adopt wraps a copy of content inserted without markers, as older
+// versions did, in a block named id.
+func adopt(file, open, close, id, content, indent string) (string, bool) {
+	existing := indentLines(content, indent) + "\n"
+	at := lineIndex(file, existing)
+	if at < 0 {
+		return file, false
+	}
+	return file[:at] + wrap(open, close, id, content, indent) + file[at+len(existing):], true
+}
+
+// Remove deletes every block named id and reports how many there were.
+func Remove(file, id string) (string, int, error) {
+	blocks, err := Find(file, id)
+	if err != nil {
+		return "", 0, err
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		file = file[:blocks[i].Start] + file[blocks[i].End:]
+	}
+	return file, len(blocks), nil
+}
+
+// RemoveCopy deletes the first run of lines matching content, ignoring
+// indentation, for snippets inserted before blocks were marked.
+func RemoveCopy(file, content string) (string, bool) {
+	want := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	lines := strings.SplitAfter(file, "\n")
+	for i := 0; i+len(want) <= len(lines); i++ {
+		matched := true
+		for j, line := range want {
+			if strings.TrimSpace(lines[i+j]) != strings.TrimSpace(line) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return strings.Join(lines[:i], "") + strings.Join(lines[i+len(want):], ""), true
+		}
+	}
+	return file, false
+}
+
+type anchorLine struct {
+	start, end int
+	indent     string
+}
+
+// anchorLines returns the lines whose only content is anchor.
+func anchorLines(file, anchor string) []anchorLine {
+	var anchors []anchorLine
+	offset := 0
+	for line := range strings.SplitAfterSeq(file, "\n") {
+		start := offset
+		offset += len(line)
+		text := strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(text) != anchor {
+			continue
+		}
+		anchors = append(anchors, anchorLine{
+			start:  start,
+			end:    offset,
+			indent: text[:len(text)-len(strings.TrimLeft(text, " \t"))],
+		})
+	}
+	return anchors
+}
+
+// lineIndex returns the offset of s in file when it starts at the beginning
+// of a line, or -1.
+func lineIndex(file, s string) int {
+	for from := 0; ; {
+		i := strings.Index(file[from:], s)
+		if i < 0 {
+			return -1
+		}
+		i += from
+		if i == 0 || file[i-1] == '\n' {
+			return i
+		}
+		from = i + 1
+	}
+}
+
+// commentStyle picks the comment syntax of the anchor for the marker lines,
+// falling back to "//".
+func commentStyle(anchor string) (open, close string) {
+	for _, style := range [][2]string{{"{/*", "*/}"}, {"/*", "*/"}, {"<!--", "-->"}, {"//", ""}, {"#", ""}} {
+		if strings.HasPrefix(anchor, style[0]) {
+			return style[0], style[1]
+		}
+	}
+	return "//", ""
+}
+
+func wrap(open, close, id, content, indent string) string {
+	suffix := ""
+	if close != "" {
+		suffix = " " + close
+	}
+	return indent + open + " " + beginTag + " " + id + suffix + "\n" +
+		indentLines(content, indent) + "\n" +
+		indent + open + " " + endTag + suffix + "\n"
+}
+
+// indentLines prefixes every non-empty line of s with indent.
+func indentLines(s, indent string) string {
+	if indent == "" {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
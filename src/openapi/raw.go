@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// rawObject is a JSON object whose members keep their order and, unless
+// set, their exact source, so numbers such as 1.0 or 12345678901234567890
+// survive an edit of openapi.json.
+type rawObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func newRawObject() *rawObject {
+	return &rawObject{values: map[string]json.RawMessage{}}
+}
+
+// parseRawObject splits a JSON object into its members.
+func parseRawObject(data []byte) (*rawObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	o := newRawObject()
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		o.setRaw(token.(string), value)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *rawObject) has(key string) bool {
+	_, ok := o.values[key]
+	return ok
+}
+
+// child returns the object under key, or an empty one when key is missing
+// or holds something else. Changes to it are kept by setObject.
+func (o *rawObject) child(key string) *rawObject {
+	if value, ok := o.values[key]; ok {
+		if child, err := parseRawObject(value); err == nil {
+			return child
+		}
+	}
+	return newRawObject()
+}
+
+// set stores value under key, after the existing members when key is new.
+func (o *rawObject) set(key string, value any) {
+	data, _ := json.Marshal(value)
+	o.setRaw(key, data)
+}
+
+func (o *rawObject) setObject(key string, child *rawObject) {
+	o.setRaw(key, child.encode())
+}
+
+func (o *rawObject) setRaw(key string, value json.RawMessage) {
+	if !o.has(key) {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// delete removes key and reports whether it was there.
+func (o *rawObject) delete(key string) bool {
+	if !o.has(key) {
+		return false
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (o *rawObject) encode() []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(o.values[key])
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+// document formats o as the contents of openapi.json. Indenting changes
+// only whitespace, never the members or their values.
+func (o *rawObject) document() ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, o.encode(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
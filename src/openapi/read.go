@@ -0,0 +1,317 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/fields"
+	"github.com/sohel902833/go_super_cli/src/types"
+	"gopkg.in/yaml.v3"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// schema is the subset of an OpenAPI 3 schema object the generator reads.
+// Properties is kept as a node so their declaration order survives.
+type schema struct {
+	Ref         string    `yaml:"$ref"`
+	Type        yaml.Node `yaml:"type"`
+	Format      string    `yaml:"format"`
+	Description string    `yaml:"description"`
+	Enum        []any     `yaml:"enum"`
+	Default     any       `yaml:"default"`
+	Required    []string  `yaml:"required"`
+	Properties  yaml.Node `yaml:"properties"`
+	Items       *schema   `yaml:"items"`
+	AllOf       []*schema `yaml:"allOf"`
+	ReadOnly    bool      `yaml:"readOnly"`
+	MinLength   *float64  `yaml:"minLength"`
+	MaxLength   *float64  `yaml:"maxLength"`
+	Minimum     *float64  `yaml:"minimum"`
+	Maximum     *float64  `yaml:"maximum"`
+	Pattern     string    `yaml:"pattern"`
+	Unique      bool      `yaml:"x-unique"`
+	Index       bool      `yaml:"x-index"`
+}
+
+type property struct {
+	name   string
+	schema *schema
+}
+
+// typeName returns the schema's type, ignoring "null" in OpenAPI 3.1 type
+// lists.
+func (s *schema) typeName() string {
+	if s.Type.Kind == yaml.SequenceNode {
+		for _, item := range s.Type.Content {
+			if item.Value != "null" {
+				return item.Value
+			}
+		}
+	}
+	return s.Type.Value
+}
+
+func (s *schema) properties() ([]property, error) {
+	var properties []property
+	for i := 0; i+1 < len(s.Properties.Content); i += 2 {
+		var p schema
+		if err := s.Properties.Content[i+1].Decode(&p); err != nil {
+			return nil, err
+		}
+		properties = append(properties, property{s.Properties.Content[i].Value, &p})
+	}
+	return properties, nil
+}
+
+// Read turns every object schema under components.schemas of an OpenAPI 3
+// document (YAML or JSON) into a module, named after the schema in
+// camelCase. Properties that $ref another object schema become references
+// to that module; $refs to anything else are inlined. Parts the generator
+// cannot express are skipped and reported in the returned warnings. The
+// x-unique and x-index extensions mark unique and indexed properties.
+func Read(data []byte) ([]types.Module, []string, error) {
+	var document struct {
+		OpenAPI    string `yaml:"openapi"`
+		Components struct {
+			Schemas yaml.Node `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3") {
+		return nil, nil, fmt.Errorf("expected an OpenAPI 3 document, got openapi: %q", document.OpenAPI)
+	}
+
+	r := &reader{schemas: map[string]*schema{}}
+	var names []string
+	content := document.Components.Schemas.Content
+	for i := 0; i+1 < len(content); i += 2 {
+		var s schema
+		if err := content[i+1].Decode(&s); err != nil {
+			return nil, nil, fmt.Errorf("components.schemas.%s: %w", content[i].Value, err)
+		}
+		r.schemas[content[i].Value] = &s
+		names = append(names, content[i].Value)
+	}
+
+	var modules []types.Module
+	for _, name := range names {
+		if !r.isObject(r.schemas[name]) {
+			r.warnf("skipped %s: not an object schema", name)
+			continue
+		}
+		properties, required, err := r.flatten(r.schemas[name], nil)
+		if err != nil {
+			return nil, r.warnings, fmt.Errorf("components.schemas.%s: %w", name, err)
+		}
+		definitions, err := r.definitions(properties, required, name+".", true)
+		if err != nil {
+			return nil, r.warnings, fmt.Errorf("components.schemas.%s: %w", name, err)
+		}
+		modules = append(modules, types.Module{
+			ModuleName: strings.ToLower(name[:1]) + name[1:],
+			Fields:     definitions,
+		})
+	}
+	if len(modules) == 0 {
+		return nil, r.warnings, fmt.Errorf("no object schemas found under components.schemas")
+	}
+	return modules, r.warnings, nil
+}
+
+type reader struct {
+	schemas  map[string]*schema
+	warnings []string
+}
+
+func (r *reader) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// resolve follows s's $ref, if any. visited guards against cycles.
+func (r *reader) resolve(s *schema, visited []string) (*schema, string, error) {
+	if s.Ref == "" {
+		return s, "", nil
+	}
+	name, ok := strings.CutPrefix(s.Ref, schemaRefPrefix)
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported $ref %q, only local %s refs are read", s.Ref, schemaRefPrefix)
+	}
+	target, ok := r.schemas[name]
+	if !ok {
+		return nil, "", fmt.Errorf("$ref %q points at no schema", s.Ref)
+	}
+	for _, v := range visited {
+		if v == name {
+			return nil, "", fmt.Errorf("circular $ref %q", s.Ref)
+		}
+	}
+	return target, name, nil
+}
+
+// isObject reports whether s describes an object with properties, directly
+// or through allOf.
+func (r *reader) isObject(s *schema) bool {
+	if len(s.Properties.Content) > 0 {
+		return true
+	}
+	for _, part := range s.AllOf {
+		if resolved, _, err := r.resolve(part, nil); err == nil && r.isObject(resolved) {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten returns the properties and required names of an object schema,
+// merging allOf parts in order.
+func (r *reader) flatten(s *schema, visited []string) ([]property, []string, error) {
+	properties, err := s.properties()
+	if err != nil {
+		return nil, nil, err
+	}
+	required := s.Required
+	for _, part := range s.AllOf {
+		resolved, name, err := r.resolve(part, visited)
+		if err != nil {
+			return nil, nil, err
+		}
+		partProperties, partRequired, err := r.flatten(resolved, append(visited, name))
+		if err != nil {
+			return nil, nil, err
+		}
+		properties = append(partProperties, properties...)
+		required = append(required, partRequired...)
+	}
+	return properties, required, nil
+}
+
+func (r *reader) definitions(properties []property, required []string, path string, top bool) ([]types.FieldDefinition, error) {
+	var definitions []types.FieldDefinition
+	for _, p := range properties {
+		if err := fields.CheckName(p.name, top); err != nil {
+			r.warnf("skipped %s%s: %v", path, p.name, err)
+			continue
+		}
+		if p.schema.ReadOnly {
+			r.warnf("skipped %s%s: readOnly", path, p.name)
+			continue
+		}
+		definition, ok, err := r.definition(p.name, path+p.name, p.schema, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s: %w", path, p.name, err)
+		}
+		if !ok {
+			continue
+		}
+		for _, name := range required {
+			if name == p.name {
+				definition.Required = true
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// definition converts one property schema. ok is false when the property
+// was skipped with a warning.
+func (r *reader) definition(name, path string, s *schema, visited []string) (types.FieldDefinition, bool, error) {
+	definition := types.FieldDefinition{Name: name, Description: s.Description}
+
+	// A lone allOf wrapping a $ref is the usual way to describe a reference.
+	if s.Ref == "" && len(s.AllOf) == 1 && s.AllOf[0].Ref != "" && len(s.Properties.Content) == 0 {
+		s = &schema{Ref: s.AllOf[0].Ref, Description: s.Description}
+	}
+	if s.Ref != "" {
+		target, targetName, err := r.resolve(s, visited)
+		if err != nil {
+			return definition, false, err
+		}
+		if r.isObject(target) {
+			definition.Type = "ref"
+			definition.Ref = strings.ToUpper(targetName[:1]) + targetName[1:]
+			return definition, true, nil
+		}
+		inlined, ok, err := r.definition(name, path, target, append(visited, targetName))
+		if inlined.Description == "" {
+			inlined.Description = s.Description
+		}
+		return inlined, ok, err
+	}
+
+	switch s.typeName() {
+	case "array":
+		if s.Items == nil {
+			r.warnf("skipped %s: array without items", path)
+			return definition, false, nil
+		}
+		item, ok, err := r.definition(name, path, s.Items, visited)
+		if err != nil || !ok {
+			return definition, ok, err
+		}
+		if item.Array {
+			r.warnf("skipped %s: nested arrays are not supported", path)
+			return definition, false, nil
+		}
+		item.Array = true
+		item.Default = nil
+		if item.Description == "" {
+			item.Description = s.Description
+		}
+		return item, true, nil
+	case "object", "":
+		if !r.isObject(s) {
+			r.warnf("skipped %s: free-form objects are not supported", path)
+			return definition, false, nil
+		}
+		properties, required, err := r.flatten(s, visited)
+		if err != nil {
+			return definition, false, err
+		}
+		children, err := r.definitions(properties, required, path+".", false)
+		if err != nil {
+			return definition, false, err
+		}
+		if len(children) == 0 {
+			r.warnf("skipped %s: no usable properties", path)
+			return definition, false, nil
+		}
+		definition.Type = "object"
+		definition.Fields = children
+		return definition, true, nil
+	case "string":
+		definition.Type = "string"
+		switch s.Format {
+		case "date", "date-time":
+			definition.Type = "date"
+		}
+		if len(s.Enum) > 0 {
+			definition.Type = "enum"
+			for _, value := range s.Enum {
+				definition.Enum = append(definition.Enum, fmt.Sprint(value))
+			}
+		}
+		definition.Validations.Min = s.MinLength
+		definition.Validations.Max = s.MaxLength
+		definition.Validations.Pattern = s.Pattern
+	case "integer", "number":
+		definition.Type = "number"
+		if len(s.Enum) > 0 {
+			r.warnf("%s: numeric enum dropped", path)
+		}
+		definition.Validations.Min = s.Minimum
+		definition.Validations.Max = s.Maximum
+	case "boolean":
+		definition.Type = "boolean"
+	default:
+		r.warnf("skipped %s: unsupported type %q", path, s.typeName())
+		return definition, false, nil
+	}
+	definition.Validations.Unique = s.Unique
+	definition.Validations.Index = s.Index
+	definition.Default = s.Default
+	return definition, true, nil
+}
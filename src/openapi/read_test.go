@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReadWarnsAboutSkippedSchemas(t *testing.T) {
+	spec := `openapi: 3.0.3
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [open, closed]
+    Order:
+      type: object
+      properties:
+        title:
+          type: string
+        status:
+          $ref: '#/components/schemas/Status'
+`
+	modules, warnings, err := Read([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 1 || modules[0].ModuleName != "order" {
+		t.Errorf("modules = %+v, want only order", modules)
+	}
+	if !slices.Contains(warnings, "skipped Status: not an object schema") {
+		t.Errorf("warnings = %q, want the skipped Status schema", warnings)
+	}
+}
@@ -0,0 +1,335 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sohel902833/go_super_cli/src/render"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// FileName is the spec file kept at the project root.
+const FileName = "openapi.json"
+
+// object is a JSON object in the spec.
+type object = map[string]any
+
+// Merge adds the paths and schemas of the module described by ctx to spec,
+// the current contents of openapi.json, and returns the new contents. An
+// empty spec starts a new document. Entries for the module are replaced
+// as a whole; everything else in the document is kept as written.
+func Merge(spec []byte, ctx render.Context) ([]byte, error) {
+	document := newRawObject()
+	if len(spec) > 0 {
+		var err error
+		if document, err = parseRawObject(spec); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", FileName, err)
+		}
+	}
+	if !document.has("openapi") {
+		title := ctx.Project.Name
+		if title == "" {
+			title = "API"
+		}
+		document.set("openapi", "3.0.3")
+		document.set("info", object{"title": title, "version": "1.0.0"})
+		document.set("servers", []any{object{"url": "/api/v1"}})
+	}
+
+	paths := document.child("paths")
+	components := document.child("components")
+	schemas := components.child("schemas")
+	securitySchemes := components.child("securitySchemes")
+	if !securitySchemes.has("authorization") {
+		securitySchemes.set("authorization", object{
+			"type":        "apiKey",
+			"in":          "header",
+			"name":        "Authorization",
+			"description": "The access token returned by the login route.",
+		})
+	}
+
+	name := ctx.PascalCaseModuleName
+	schemas.set(name, moduleSchema(ctx.Fields))
+	schemas.set("Create"+name+"DTO", objectSchema(ctx.Fields, true))
+	schemas.set("Edit"+name+"DTO", objectSchema(ctx.Fields, false))
+
+	base := "/" + ctx.LowerCaseModuleName
+	paths.set(base, object{
+		"post": operation(ctx, "createNew"+name, "Create a "+name, true).
+			body("Create"+name+"DTO").
+			respond("201", envelope(name, true)),
+		"get": operation(ctx, "getAll"+name, "List "+name+" records", false).
+			params(listParameters(ctx.Fields)).
+			respond("200", pageSchema(name)),
+	})
+	paths.set(base+"/{id}", object{
+		"put": operation(ctx, "update"+name, "Update a "+name, true).
+			params([]any{idParameter}).
+			body("Edit"+name+"DTO").
+			respond("200", envelope(name, true)),
+		"delete": operation(ctx, "delete"+name, "Delete a "+name, true).
+			params([]any{idParameter}).
+			respond("200", envelope(name, false)),
+	})
+	paths.set(base+"/single/{id}", object{
+		"get": operation(ctx, "getSingle"+name, "Get a "+name, false).
+			params([]any{idParameter}).
+			respond("200", ref(name)),
+	})
+
+	components.setObject("schemas", schemas)
+	components.setObject("securitySchemes", securitySchemes)
+	document.setObject("paths", paths)
+	document.setObject("components", components)
+	return document.document()
+}
+
+// Remove deletes the paths and schemas Merge added for the module described
+// by ctx. changed is false when spec has none of them.
+func Remove(spec []byte, ctx render.Context) ([]byte, bool, error) {
+	document, err := parseRawObject(spec)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", FileName, err)
+	}
+	changed := false
+	paths := document.child("paths")
+	base := "/" + ctx.LowerCaseModuleName
+	for _, path := range []string{base, base + "/{id}", base + "/single/{id}"} {
+		changed = paths.delete(path) || changed
+	}
+	components := document.child("components")
+	schemas := components.child("schemas")
+	name := ctx.PascalCaseModuleName
+	for _, schema := range []string{name, "Create" + name + "DTO", "Edit" + name + "DTO"} {
+		changed = schemas.delete(schema) || changed
+	}
+	if !changed {
+		return spec, false, nil
+	}
+	if document.has("paths") {
+		document.setObject("paths", paths)
+	}
+	if components.has("schemas") {
+		components.setObject("schemas", schemas)
+		document.setObject("components", components)
+	}
+	out, err := document.document()
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+type op object
+
+func operation(ctx render.Context, id, summary string, secured bool) op {
+	o := op{
+		"operationId": id,
+		"summary":     summary,
+		"tags":        []any{ctx.PascalCaseModuleName},
+		"responses":   object{},
+	}
+	if secured {
+		o["security"] = []any{object{"authorization": []any{}}}
+	}
+	return o
+}
+
+func (o op) params(parameters []any) op {
+	o["parameters"] = parameters
+	return o
+}
+
+func (o op) body(schemaName string) op {
+	o["requestBody"] = object{
+		"required": true,
+		"content":  object{"application/json": object{"schema": ref(schemaName)}},
+	}
+	return o
+}
+
+func (o op) respond(status string, schema object) op {
+	o["responses"].(object)[status] = object{
+		"description": "Success",
+		"content":     object{"application/json": object{"schema": schema}},
+	}
+	return o
+}
+
+var idParameter = object{
+	"name":     "id",
+	"in":       "path",
+	"required": true,
+	"schema":   objectIDSchema(),
+}
+
+func ref(schemaName string) object {
+	return object{"$ref": "#/components/schemas/" + schemaName}
+}
+
+func objectIDSchema() object {
+	return object{"type": "string", "pattern": "^[0-9a-fA-F]{24}$"}
+}
+
+// listParameters describes the query string modifyQuery reads: page and
+// limit, a _sort_<field> parameter per sortable field, and the remaining
+// keys as filters.
+func listParameters(fields []types.Field) []any {
+	parameters := []any{
+		object{"name": "page", "in": "query", "schema": object{"type": "integer", "minimum": 1, "default": 1}},
+		object{"name": "limit", "in": "query", "schema": object{"type": "integer", "minimum": 1, "default": 10}},
+	}
+	var scalars []string
+	for _, f := range fields {
+		if f.Type != "O" && !f.Array {
+			scalars = append(scalars, f.Name)
+		}
+	}
+	for _, name := range append(scalars, "createdAt", "updatedAt") {
+		parameters = append(parameters, object{
+			"name":        "_sort_" + name,
+			"in":          "query",
+			"description": "Sort by " + name + ". Newest first by createdAt when no sort is given.",
+			"schema":      object{"type": "string", "enum": []any{"asc", "desc"}},
+		})
+	}
+	for _, name := range scalars {
+		parameters = append(parameters, object{
+			"name":        name,
+			"in":          "query",
+			"description": "Filter by " + name + ". Text matches case-insensitively; separate values with commas to match any of them.",
+			"schema":      object{"type": "string"},
+		})
+	}
+	return parameters
+}
+
+func envelope(name string, success bool) object {
+	properties := object{
+		"message": object{"type": "string"},
+		"data":    ref(name),
+	}
+	if success {
+		properties["success"] = object{"type": "boolean"}
+	}
+	return object{"type": "object", "properties": properties}
+}
+
+func pageSchema(name string) object {
+	return object{
+		"type": "object",
+		"properties": object{
+			"pagination": object{
+				"type": "object",
+				"properties": object{
+					"total":     object{"type": "integer"},
+					"next_page": object{"type": "integer"},
+					"prev_page": object{"type": "integer"},
+					"limit":     object{"type": "integer"},
+				},
+			},
+			"data": object{"type": "array", "items": ref(name)},
+		},
+	}
+}
+
+// moduleSchema describes a stored document: the fields plus those the
+// generator and Mongoose add.
+func moduleSchema(fields []types.Field) object {
+	schema := objectSchema(fields, true)
+	properties := schema["properties"].(object)
+	properties["_id"] = withReadOnly(objectIDSchema())
+	properties["creator"] = withReadOnly(objectIDSchema())
+	properties["createdAt"] = object{"type": "string", "format": "date-time", "readOnly": true}
+	properties["updatedAt"] = object{"type": "string", "format": "date-time", "readOnly": true}
+	required, _ := schema["required"].([]any)
+	schema["required"] = append([]any{"_id"}, required...)
+	return schema
+}
+
+func withReadOnly(schema object) object {
+	schema["readOnly"] = true
+	return schema
+}
+
+// objectSchema describes fields as an object. With required false, the top
+// level fields are all optional, as in the edit DTO.
+func objectSchema(fields []types.Field, required bool) object {
+	properties := object{}
+	names := []any{}
+	for _, f := range fields {
+		properties[f.Name] = fieldSchema(f)
+		if required && f.Required {
+			names = append(names, f.Name)
+		}
+	}
+	schema := object{"type": "object", "properties": properties}
+	if len(names) > 0 {
+		schema["required"] = names
+	}
+	return schema
+}
+
+func fieldSchema(f types.Field) object {
+	var schema object
+	switch f.Type {
+	case "S":
+		schema = object{"type": "string"}
+		setNumber(schema, "minLength", f.Min)
+		setNumber(schema, "maxLength", f.Max)
+		setNumber(schema, "minLength", f.Length)
+		setNumber(schema, "maxLength", f.Length)
+		if f.Pattern != "" {
+			schema["pattern"] = f.Pattern
+		}
+	case "N":
+		schema = object{"type": "number"}
+		setNumber(schema, "minimum", f.Min)
+		setNumber(schema, "maximum", f.Max)
+	case "B":
+		schema = object{"type": "boolean"}
+	case "D":
+		schema = object{"type": "string", "format": "date-time"}
+	case "E":
+		values := make([]any, len(f.Enum))
+		for i, v := range f.Enum {
+			values[i] = v
+		}
+		schema = object{"type": "string", "enum": values}
+	case "REF":
+		schema = objectIDSchema()
+		schema["description"] = "Id of a " + f.Ref
+	case "O":
+		schema = objectSchema(f.Fields, true)
+	default:
+		// Custom type codes from the project config have no known shape.
+		schema = object{}
+	}
+
+	switch {
+	case f.Default == "", f.Type == "D" && f.Default == "now":
+	case f.Type == "N":
+		if n, err := strconv.ParseFloat(f.Default, 64); err == nil {
+			schema["default"] = n
+		}
+	case f.Type == "B":
+		schema["default"] = f.Default == "true"
+	default:
+		schema["default"] = f.Default
+	}
+
+	if f.Array {
+		schema = object{"type": "array", "items": schema}
+	}
+	if f.Description != "" {
+		schema["description"] = f.Description
+	}
+	return schema
+}
+
+func setNumber(schema object, key, value string) {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		schema[key] = n
+	}
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sohel902833/go_super_cli/src/render"
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// Entries written by hand must come through Merge and Remove unchanged:
+// member order, big integers and floats such as 1.0.
+func TestMergeKeepsHandWrittenContent(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.3","info":{"title":"Hand","version":"1.0"},"x-ratio":1.0,` +
+		`"paths":{"/zeta":{"x-id":12345678901234567890},"/alpha":{}},` +
+		`"components":{"securitySchemes":{"authorization":{"type":"http"}},"schemas":{"Zed":{"type":"object"}}}}`)
+	ctx := render.Context{
+		ModuleName:           "order",
+		LowerCaseModuleName:  "order",
+		PascalCaseModuleName: "Order",
+		Fields:               []types.Field{{Name: "title", Type: "S", Required: true}},
+	}
+
+	merged, err := Merge(spec, ctx)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	for _, want := range []string{`"x-ratio": 1.0`, `"x-id": 12345678901234567890`, `"type": "http"`, `"/order/single/{id}"`, `"CreateOrderDTO"`} {
+		if !bytes.Contains(merged, []byte(want)) {
+			t.Errorf("merged spec lacks %s", want)
+		}
+	}
+	order := []string{`"openapi"`, `"info"`, `"x-ratio"`, `"paths"`, `"/zeta"`, `"/alpha"`, `"/order"`, `"components"`}
+	last := -1
+	for _, key := range order {
+		i := bytes.Index(merged, []byte(key))
+		if i < last {
+			t.Errorf("%s moved before the members that preceded it", key)
+		}
+		last = i
+	}
+
+	removed, changed, err := Remove(merged, ctx)
+	if err != nil || !changed {
+		t.Fatalf("Remove: changed %v, %v", changed, err)
+	}
+	want := `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Hand",
+    "version": "1.0"
+  },
+  "x-ratio": 1.0,
+  "paths": {
+    "/zeta": {
+      "x-id": 12345678901234567890
+    },
+    "/alpha": {}
+  },
+  "components": {
+    "securitySchemes": {
+      "authorization": {
+        "type": "http"
+      }
+    },
+    "schemas": {
+      "Zed": {
+        "type": "object"
+      }
+    }
+  }
+}
+`
+	if string(removed) != want {
+		t.Errorf("after Remove got\n%s\nwant\n%s", removed, want)
+	}
+	if _, changed, _ := Remove(removed, ctx); changed {
+		t.Error("second Remove reported a change")
+	}
+	if strings.Count(string(merged), `"Order"`) == 0 {
+		t.Error("merged spec has no Order tag")
+	}
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// Project holds project-wide settings available to every template as
+// .Project.
+type Project struct {
+	Name           string
+	Port           string
+	DatabaseURL    string
+	PackageManager string
+	// Vars holds extra values such as config defaults, keyed by token name.
+	Vars map[string]string
+}
+
+// Context is the data a template is executed with.
+type Context struct {
+	ModuleName           string
+	LowerCaseModuleName  string
+	UpperCaseModuleName  string
+	PascalCaseModuleName string
+	CamelCaseModuleName  string
+	Fields               []types.Field
+	Project              Project
+
+	// Legacy maps old-style {{TOKEN}} names (without braces) to their
+	// values. Each one is exposed to templates as a function of the same
+	// name, so existing {{UPPER_CASE_MODULE_NAME}} placeholders keep working.
+	Legacy map[string]string
+}
+
+// HasFieldType reports whether any field uses the given type code.
+func (c Context) HasFieldType(t string) bool {
+	for _, field := range c.Fields {
+		if field.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRequiredFields reports whether at least one field is required.
+func (c Context) HasRequiredFields() bool {
+	for _, field := range c.Fields {
+		if field.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// Render executes text as a text/template named name. funcs are added on top
+// of the legacy token functions and may override them.
+//
+// An upper-case {{TOKEN}} that is neither a legacy token nor one of funcs is
+// left in the output as written, as plain token replacement used to do. Any
+// other "{{" starts template syntax, so a literal one must be written as
+// {{"{{"}}; the parse error says so.
+func Render(name, text string, ctx Context, funcs template.FuncMap) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	text = legacyTokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		name := legacyTokenPattern.FindStringSubmatch(token)[1]
+		if _, ok := ctx.Legacy[name]; ok {
+			return token
+		}
+		if _, ok := funcs[name]; ok {
+			return token
+		}
+		return `{{"` + token + `"}}`
+	})
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(legacyFuncs(ctx.Legacy)).
+		Funcs(funcs).
+		Parse(text)
+	if err != nil {
+		return "", fmt.Errorf(`%w (a literal "{{" must be written as {{"{{"}})`, err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, ctx); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// legacyTokenPattern matches an old-style {{TOKEN}} placeholder.
+	legacyTokenPattern = regexp.MustCompile(`\{\{\s*([A-Z][A-Z0-9_]*)\s*\}\}`)
+)
+
+func legacyFuncs(legacy map[string]string) template.FuncMap {
+	funcs := make(template.FuncMap, len(legacy))
+	for name, value := range legacy {
+		// text/template panics on function names that are not identifiers;
+		// such tokens could never be referenced from a template anyway.
+		if !identifierPattern.MatchString(name) {
+			continue
+		}
+		funcs[name] = func() string { return value }
+	}
+	return funcs
+}
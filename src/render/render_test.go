@@ -0,0 +1,41 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	ctx := Context{
+		ModuleName: "order",
+		Legacy:     map[string]string{"PASCAL_CASE_MODULE_NAME": "Order"},
+	}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"no placeholders", "no placeholders"},
+		{"class {{PASCAL_CASE_MODULE_NAME}}Service", "class OrderService"},
+		{"{{.ModuleName}}.routes.ts", "order.routes.ts"},
+		{"{{if .ModuleName}}yes{{end}}", "yes"},
+		{"keep {{UNKNOWN_TOKEN}} and {{ OTHER }}", "keep {{UNKNOWN_TOKEN}} and {{ OTHER }}"},
+		{`<div style={{"{{"}} color: 'red' }}>`, "<div style={{ color: 'red' }}>"},
+	}
+	for _, tt := range tests {
+		got, err := Render("test", tt.text, ctx, nil)
+		if err != nil {
+			t.Errorf("Render(%q): %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLiteralBraces(t *testing.T) {
+	_, err := Render("test", "<div style={{ color: 'red' }}>", Context{}, nil)
+	if err == nil || !strings.Contains(err.Error(), `a literal "{{" must be written as {{"{{"}}`) {
+		t.Errorf("Render error = %v, want the literal brace hint", err)
+	}
+}
@@ -0,0 +1,174 @@
+package templates
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/types"
+)
+
+// Extension is the suffix every template file must carry.
+const Extension = ".tmpl"
+
+const frontMatterDelimiter = "---"
+
+// Load reads every *.tmpl file in defaults and, when overrideDir exists, in
+// overrideDir. A file in overrideDir replaces the default with the same
+// relative name; new names are added. Files are returned in name order.
+//
+// Each template starts with a front-matter block:
+//
+//	---
+//	path: src/modules/{{LOWER_CASE_MODULE_NAME}}/{{LOWER_CASE_MODULE_NAME}}.routes.ts
+//	description: Creating routes file
+//	---
+//
+// path defaults to the template's relative name without .tmpl. A template
+// that sets placeholder becomes an update instruction; position and
+// createIfNotExists are then read as well, and the trailing newline of the
+// body is dropped since the snippet is spliced in next to the placeholder.
+// block names the marker block the snippet is wrapped in and defaults to
+// the template's base name; target is where the snippet goes when the
+// file lacks the placeholder.
+func Load(defaults fs.FS, overrideDir string) ([]types.FileInstruction, []types.UpdateInstruction, error) {
+	sources := map[string]fs.FS{}
+	if err := collect(defaults, sources); err != nil {
+		return nil, nil, err
+	}
+	if overrideDir != "" {
+		if info, err := os.Stat(overrideDir); err == nil && info.IsDir() {
+			if err := collect(os.DirFS(overrideDir), sources); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", overrideDir, err)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fileInstructions []types.FileInstruction
+	var updateInstructions []types.UpdateInstruction
+	for _, name := range names {
+		data, err := fs.ReadFile(sources[name], name)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, update, err := Parse(name, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if update != nil {
+			updateInstructions = append(updateInstructions, *update)
+		} else {
+			fileInstructions = append(fileInstructions, *file)
+		}
+	}
+	return fileInstructions, updateInstructions, nil
+}
+
+func collect(fsys fs.FS, sources map[string]fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasSuffix(name, Extension) {
+			sources[name] = fsys
+		}
+		return nil
+	})
+}
+
+// Parse turns a single template file into either a file instruction or, if
+// its front matter sets a placeholder, an update instruction.
+func Parse(name string, data []byte) (*types.FileInstruction, *types.UpdateInstruction, error) {
+	meta, content, err := splitFrontMatter(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	target := meta["path"]
+	if target == "" {
+		target = strings.TrimSuffix(path.Clean(name), Extension)
+	}
+
+	if placeholder, ok := meta["placeholder"]; ok {
+		createIfNotExists := false
+		if value, ok := meta["createIfNotExists"]; ok {
+			createIfNotExists, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: createIfNotExists: %w", name, err)
+			}
+		}
+		return nil, &types.UpdateInstruction{
+			FilePath:          target,
+			Placeholder:       placeholder,
+			Content:           strings.TrimSuffix(content, "\n"),
+			Position:          meta["position"],
+			CreateIfNotExists: createIfNotExists,
+			Description:       meta["description"],
+			Block:             cmp.Or(meta["block"], strings.TrimSuffix(path.Base(name), Extension)),
+			Target:            meta["target"],
+		}, nil
+	}
+
+	return &types.FileInstruction{
+		FilePath:    target,
+		Content:     content,
+		Description: meta["description"],
+	}, nil, nil
+}
+
+// splitFrontMatter separates the leading "---" block of key: value lines
+// from the template body. Values may be wrapped in double quotes to keep
+// leading or trailing spaces.
+func splitFrontMatter(text string) (map[string]string, string, error) {
+	meta := map[string]string{}
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return meta, text, nil
+	}
+
+	rest := text[len(frontMatterDelimiter)+1:]
+	end := strings.Index(rest, "\n"+frontMatterDelimiter+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n"+frontMatterDelimiter) {
+			return nil, "", errors.New("front matter is not closed with ---")
+		}
+		end = len(rest) - len(frontMatterDelimiter) - 1
+	}
+	header := rest[:end]
+	body := ""
+	if bodyStart := end + len(frontMatterDelimiter) + 2; bodyStart < len(rest) {
+		body = rest[bodyStart:]
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(header))
+	line := 1
+	for scanner.Scan() {
+		line++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(raw, ":")
+		if !ok {
+			return nil, "", fmt.Errorf("front matter line %d: expected \"key: value\"", line)
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+		meta[strings.TrimSpace(key)] = value
+	}
+	return meta, body, nil
+}
@@ -0,0 +1,265 @@
+// Package tsedit finds where new entries go in TypeScript files by their
+// structure, for updates whose placeholder comment is missing.
+package tsedit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sohel902833/go_super_cli/src/tslex"
+)
+
+// ImportTarget is the target naming the file's import list.
+const ImportTarget = "import"
+
+const defaultIndent = "    "
+
+// Point is where a new entry goes.
+type Point struct {
+	Src    string // the source, with a comma added after the last entry when it lacked one
+	At     int    // offset of the line the entry is inserted before
+	Indent string // indentation of the entries
+}
+
+// Locate finds where a new entry for target goes in src. target is
+// ImportTarget for a new import statement, or the name of a top-level
+// const, let, var or enum whose value is an array or object literal, or a
+// call taking one such as createBrowserRouter([...]). Further ".key"
+// segments descend into the literal's properties; in an array, the first
+// object element that has the key is used, so "router.children" finds the
+// children of a route. Entries go after the last existing one.
+func Locate(src, target string) (Point, error) {
+	tokens, err := tslex.Lex(src)
+	if err != nil {
+		return Point{}, err
+	}
+	if target == ImportTarget {
+		return Point{Src: src, At: lineAfter(src, lastImportEnd(tokens))}, nil
+	}
+
+	segments := strings.Split(target, ".")
+	open := declaration(tokens, segments[0])
+	if open < 0 {
+		return Point{}, fmt.Errorf("no top-level declaration of %s with an array or object value", segments[0])
+	}
+	for _, key := range segments[1:] {
+		if open = property(tokens, open, key); open < 0 {
+			return Point{}, fmt.Errorf("%s has no array or object property %s", target, key)
+		}
+	}
+	return entryPoint(src, tokens, open, matching(tokens, open), target)
+}
+
+// HasImport reports whether src already imports the module that the import
+// statement imports.
+func HasImport(src, statement string) bool {
+	module := importSource(statement)
+	if module == "" {
+		return false
+	}
+	tokens, err := tslex.Lex(src)
+	if err != nil {
+		return false
+	}
+	for i, t := range tokens {
+		if t.Kind == tslex.String && t.Text == module && i > 0 && (tokens[i-1].Text == "from" || tokens[i-1].Text == "import") {
+			return true
+		}
+	}
+	return false
+}
+
+func importSource(statement string) string {
+	tokens, err := tslex.Lex(statement)
+	if err != nil {
+		return ""
+	}
+	for _, t := range tokens {
+		if t.Kind == tslex.String {
+			return t.Text
+		}
+	}
+	return ""
+}
+
+// lastImportEnd returns the offset after the last top-level import
+// statement, or 0. Statements without a module string, such as the alias
+// import X = Y.Z, are passed over.
+func lastImportEnd(tokens []tslex.Token) int {
+	end := 0
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if depth == 0 && t.Kind == tslex.Ident && t.Text == "import" && i+1 < len(tokens) && tokens[i+1].Text != "(" && tokens[i+1].Text != "." {
+			for i++; i < len(tokens)-1 && tokens[i].Kind != tslex.String && !endsImport(tokens[i]); i++ {
+			}
+			if tokens[i].Kind != tslex.String {
+				if tokens[i].Text != ";" {
+					i--
+				}
+				continue
+			}
+			end = tokens[i].End
+			if i+1 < len(tokens) && tokens[i+1].Text == ";" {
+				i++
+				end = tokens[i].End
+			}
+			continue
+		}
+		depth += bracketDepth(t)
+	}
+	return end
+}
+
+// endsImport reports whether t ends an import statement that has no module
+// string: its semicolon, or the first keyword of the next statement.
+func endsImport(t tslex.Token) bool {
+	if t.Kind == tslex.Punct {
+		return t.Text == ";"
+	}
+	switch t.Text {
+	case "import", "export", "const", "let", "var", "function", "class", "enum", "interface":
+		return t.Kind == tslex.Ident
+	}
+	return false
+}
+
+func bracketDepth(t tslex.Token) int {
+	if t.Kind != tslex.Punct {
+		return 0
+	}
+	switch t.Text {
+	case "(", "[", "{":
+		return 1
+	case ")", "]", "}":
+		return -1
+	}
+	return 0
+}
+
+// declaration returns the index of the opening bracket of name's value.
+func declaration(tokens []tslex.Token, name string) int {
+	depth := 0
+	for i := 0; i+2 < len(tokens); i++ {
+		t := tokens[i]
+		if depth == 0 && t.Kind == tslex.Ident && tokens[i+1].Text == name {
+			switch t.Text {
+			case "enum":
+				if tokens[i+2].Text == "{" {
+					return i + 2
+				}
+			case "const", "let", "var":
+				s := tslex.NewStream(tokens[i+2:])
+				if s.Accept(":") {
+					s.SkipType()
+				}
+				if s.Accept("=") {
+					return literal(tokens, i+2+s.Index())
+				}
+			}
+		}
+		depth += bracketDepth(t)
+	}
+	return -1
+}
+
+// literal returns the index of the array or object literal an expression
+// starting at i evaluates to: the literal itself, or the first one passed
+// to a call such as Object.freeze({...}). It returns -1 otherwise.
+func literal(tokens []tslex.Token, i int) int {
+	for i < len(tokens) {
+		switch t := tokens[i]; {
+		case t.Kind == tslex.Punct && (t.Text == "[" || t.Text == "{"):
+			return i
+		case t.Kind == tslex.Ident || t.Text == ".":
+			i++
+		case t.Text == "<":
+			i = matching(tokens, i) + 1
+		case t.Text == "(":
+			i++
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// matching returns the index of the bracket closing the one at open.
+func matching(tokens []tslex.Token, open int) int {
+	closing := map[string]string{"[": "]", "{": "}", "(": ")", "<": ">"}[tokens[open].Text]
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].Kind != tslex.Punct {
+			continue
+		}
+		switch tokens[i].Text {
+		case tokens[open].Text:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// property returns the opening bracket of key's value in the literal at
+// open, or -1.
+func property(tokens []tslex.Token, open int, key string) int {
+	end := matching(tokens, open)
+	for i := open + 1; i < end; i++ {
+		t := tokens[i]
+		switch {
+		case tokens[open].Text == "{" && (t.Kind == tslex.Ident || t.Kind == tslex.String) && t.Text == key && tokens[i+1].Text == ":":
+			return literal(tokens, i+2)
+		case tokens[open].Text == "[" && t.Kind == tslex.Punct && t.Text == "{":
+			if found := property(tokens, i, key); found >= 0 {
+				return found
+			}
+		}
+		if bracketDepth(t) > 0 {
+			i = matching(tokens, i)
+		}
+	}
+	return -1
+}
+
+// entryPoint returns where an entry goes before the bracket at close,
+// adding a comma after the last entry when needed.
+func entryPoint(src string, tokens []tslex.Token, open, close int, target string) (Point, error) {
+	closeStart := lineStart(src, tokens[close].Pos)
+	if strings.TrimSpace(src[closeStart:tokens[close].Pos]) != "" {
+		return Point{}, fmt.Errorf("the closing %s of %s shares its line with other code", tokens[close].Text, target)
+	}
+	closeIndent := src[closeStart:tokens[close].Pos]
+
+	if close == open+1 {
+		return Point{Src: src, At: closeStart, Indent: closeIndent + defaultIndent}, nil
+	}
+	indent := closeIndent + defaultIndent
+	if first := tokens[open+1]; lineStart(src, first.Pos) != lineStart(src, tokens[open].Pos) {
+		indent = src[lineStart(src, first.Pos):first.Pos]
+	}
+	if last := tokens[close-1]; last.Text != "," {
+		src = src[:last.End] + "," + src[last.End:]
+		closeStart++
+	}
+	return Point{Src: src, At: closeStart, Indent: indent}, nil
+}
+
+func lineStart(src string, pos int) int {
+	return strings.LastIndexByte(src[:pos], '\n') + 1
+}
+
+// lineAfter returns the start of the line after the one containing pos.
+func lineAfter(src string, pos int) int {
+	if pos == 0 {
+		return 0
+	}
+	if i := strings.IndexByte(src[pos:], '\n'); i >= 0 {
+		return pos + i + 1
+	}
+	return len(src)
+}
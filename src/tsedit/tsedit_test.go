@@ -0,0 +1,141 @@
+package tsedit
+
+import (
+	"strings"
+	"testing"
+)
+
+// insert applies a located point the way markers.Insert would, without the
+// marker lines, so expectations read as plain source.
+func insert(p Point, entry string) string {
+	return p.Src[:p.At] + p.Indent + entry + "\n" + p.Src[p.At:]
+}
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		target string
+		entry  string
+		want   string
+	}{
+		{
+			name:   "after the last import",
+			src:    "import a from \"a\";\nimport { b } from \"b\"\n\nconst x = 1;\n",
+			target: ImportTarget,
+			entry:  `import c from "c";`,
+			want:   "import a from \"a\";\nimport { b } from \"b\"\nimport c from \"c\";\n\nconst x = 1;\n",
+		},
+		{
+			name:   "no imports",
+			src:    "const x = 1;\n",
+			target: ImportTarget,
+			entry:  `import c from "c";`,
+			want:   "import c from \"c\";\nconst x = 1;\n",
+		},
+		{
+			name:   "import alias without a module string",
+			src:    "import a from \"a\";\nimport X = Y.Z;\nconst x = 1;\n",
+			target: ImportTarget,
+			entry:  `import c from "c";`,
+			want:   "import a from \"a\";\nimport c from \"c\";\nimport X = Y.Z;\nconst x = 1;\n",
+		},
+		{
+			name:   "array with a comma added",
+			src:    "const routes: IRoute[] = [\n    { path: \"/a\", route: a }\n];\n",
+			target: "routes",
+			entry:  `{ path: "/b", route: b },`,
+			want:   "const routes: IRoute[] = [\n    { path: \"/a\", route: a },\n    { path: \"/b\", route: b },\n];\n",
+		},
+		{
+			name:   "empty object",
+			src:    "export const Models = {\n};\n",
+			target: "Models",
+			entry:  "Order,",
+			want:   "export const Models = {\n    Order,\n};\n",
+		},
+		{
+			name:   "enum",
+			src:    "export enum MODEL_NAMES {\n  USERS = \"User\",\n}\n",
+			target: "MODEL_NAMES",
+			entry:  `ORDER = "Order",`,
+			want:   "export enum MODEL_NAMES {\n  USERS = \"User\",\n  ORDER = \"Order\",\n}\n",
+		},
+		{
+			name:   "call argument and nested key",
+			src:    "const router = createBrowserRouter([\n  {\n    path: \"/\",\n    children: [\n      { path: \"a\" },\n    ],\n  },\n]);\n",
+			target: "router.children",
+			entry:  `{ path: "b" },`,
+			want:   "const router = createBrowserRouter([\n  {\n    path: \"/\",\n    children: [\n      { path: \"a\" },\n      { path: \"b\" },\n    ],\n  },\n]);\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Locate(tt.src, tt.target)
+			if err != nil {
+				t.Fatalf("Locate: %v", err)
+			}
+			if got := insert(p, tt.entry); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocateErrors(t *testing.T) {
+	tests := []struct {
+		src, target, want string
+	}{
+		{"const a = 1;\n", "routes", "no top-level declaration of routes"},
+		{"function f() {\n  const routes = [];\n}\n", "routes", "no top-level declaration of routes"},
+		{"const routes = [a, b];\n", "routes", "shares its line"},
+		{"const router = [{ path: \"/\" }];\n", "router.children", "has no array or object property children"},
+	}
+	for _, tt := range tests {
+		_, err := Locate(tt.src, tt.target)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Locate(%q, %q) error = %v, want it to mention %q", tt.src, tt.target, err, tt.want)
+		}
+	}
+}
+
+// Malformed sources must give an error or a point inside the source, never
+// a panic.
+func TestLocateMalformed(t *testing.T) {
+	for _, src := range []string{
+		"import X = Y.Z;",
+		"import X = Y.Z",
+		"import",
+		"import {",
+		"const routes = [",
+		"const routes = [\n{",
+		"enum E {",
+		"const",
+	} {
+		for _, target := range []string{ImportTarget, "routes", "E", "routes.children"} {
+			p, err := Locate(src, target)
+			if err == nil && (p.At < 0 || p.At > len(p.Src)) {
+				t.Errorf("Locate(%q, %q) = offset %d outside the source", src, target, p.At)
+			}
+		}
+	}
+}
+
+func TestHasImport(t *testing.T) {
+	src := "import a from \"@/modules/a\";\nimport \"./side-effect\";\n"
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{`import a from "@/modules/a";`, true},
+		{`import other from "@/modules/a";`, true},
+		{`import "./side-effect";`, true},
+		{`import b from "@/modules/b";`, false},
+		{`no module here`, false},
+	}
+	for _, tt := range tests {
+		if got := HasImport(src, tt.statement); got != tt.want {
+			t.Errorf("HasImport(%q) = %v, want %v", tt.statement, got, tt.want)
+		}
+	}
+}
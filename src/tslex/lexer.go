@@ -0,0 +1,248 @@
+// Package tslex splits TypeScript source into tokens, for the readers of
+// existing model files and the structural file updates.
+package tslex
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the kind of a token.
+type Kind int
+
+const (
+	EOF Kind = iota
+	Ident
+	String
+	Number
+	Regex
+	Punct
+)
+
+// Token is one lexed token. Pos and End are the byte offsets of its source.
+type Token struct {
+	Kind Kind
+	Text string // identifier, punctuation, literal source, or the unquoted string value
+	Pos  int
+	End  int
+}
+
+// Lex splits TypeScript source into tokens, dropping whitespace and
+// comments. It understands just enough of the language to walk object
+// literals and type declarations: strings, template literals, regular
+// expression literals, numbers, identifiers and punctuation.
+func Lex(src string) ([]Token, error) {
+	var tokens []Token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case c == '"' || c == '\'' || c == '`':
+			start := i
+			var value strings.Builder
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				value.WriteByte(src[i])
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			i++
+			tokens = append(tokens, Token{String, value.String(), start, i})
+		case c == '/' && regexAllowed(tokens):
+			start := i
+			inClass := false
+			for i++; i < len(src); i++ {
+				if src[i] == '\\' {
+					i++
+					continue
+				}
+				if src[i] == '[' {
+					inClass = true
+				} else if src[i] == ']' {
+					inClass = false
+				} else if src[i] == '/' && !inClass {
+					break
+				} else if src[i] == '\n' {
+					return nil, fmt.Errorf("unterminated regular expression at offset %d", start)
+				}
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated regular expression at offset %d", start)
+			}
+			for i++; i < len(src) && isIdentChar(src[i]); i++ {
+			}
+			tokens = append(tokens, Token{Regex, src[start:i], start, i})
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) && (isIdentChar(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, Token{Number, src[start:i], start, i})
+		case isIdentChar(c):
+			start := i
+			for i < len(src) && isIdentChar(src[i]) {
+				i++
+			}
+			tokens = append(tokens, Token{Ident, src[start:i], start, i})
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, Token{Punct, "...", i, i + 3})
+			i += 3
+		case strings.HasPrefix(src[i:], "=>"):
+			tokens = append(tokens, Token{Punct, "=>", i, i + 2})
+			i += 2
+		default:
+			tokens = append(tokens, Token{Punct, string(c), i, i + 1})
+			i++
+		}
+	}
+	return append(tokens, Token{Kind: EOF, Pos: len(src), End: len(src)}), nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// regexAllowed reports whether a "/" after tokens starts a regular
+// expression rather than a division.
+func regexAllowed(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	last := tokens[len(tokens)-1]
+	if last.Kind == Ident {
+		return last.Text == "return" || last.Text == "typeof"
+	}
+	return last.Kind == Punct && strings.Contains("(,=:[!&|?{};", last.Text)
+}
+
+// Stream is a cursor over lexed tokens.
+type Stream struct {
+	tokens []Token
+	pos    int
+}
+
+// NewStream returns a stream at the first of tokens, which must end with
+// an EOF token as returned by Lex.
+func NewStream(tokens []Token) *Stream {
+	return &Stream{tokens: tokens}
+}
+
+func (s *Stream) Peek() Token { return s.tokens[s.pos] }
+
+// Index returns the index of the current token.
+func (s *Stream) Index() int { return s.pos }
+
+// PeekAt returns the token n places after the current one, or EOF.
+func (s *Stream) PeekAt(n int) Token {
+	return s.tokens[min(s.pos+n, len(s.tokens)-1)]
+}
+
+func (s *Stream) Next() Token {
+	t := s.tokens[s.pos]
+	if t.Kind != EOF {
+		s.pos++
+	}
+	return t
+}
+
+// Back steps back over the token Next returned.
+func (s *Stream) Back() {
+	if s.pos > 0 {
+		s.pos--
+	}
+}
+
+// Is reports whether the current token is the punctuation or identifier text.
+func (s *Stream) Is(text string) bool {
+	t := s.Peek()
+	return (t.Kind == Punct || t.Kind == Ident) && t.Text == text
+}
+
+func (s *Stream) Accept(text string) bool {
+	if s.Is(text) {
+		s.Next()
+		return true
+	}
+	return false
+}
+
+// SkipBalanced skips tokens up to the end of the current expression: the
+// next ",", ";" or closing bracket at depth zero, which is not consumed.
+func (s *Stream) SkipBalanced() {
+	depth := 0
+	for t := s.Peek(); t.Kind != EOF; t = s.Peek() {
+		if t.Kind == Punct {
+			switch t.Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				if depth == 0 {
+					return
+				}
+				depth--
+			case ",", ";":
+				if depth == 0 {
+					return
+				}
+			}
+		}
+		s.Next()
+	}
+}
+
+// SkipType skips a type annotation, stopping before the "=", ",", ";" or
+// closing bracket that ends it.
+func (s *Stream) SkipType() {
+	for t := s.Peek(); t.Kind != EOF; t = s.Peek() {
+		switch {
+		case s.Is("<"):
+			s.SkipGroup("<", ">")
+		case s.Is("("):
+			s.SkipGroup("(", ")")
+		case s.Is("["):
+			s.SkipGroup("[", "]")
+		case s.Is("{"):
+			s.SkipGroup("{", "}")
+		case s.Is("=>"):
+			s.Next()
+		case s.Is("=") || s.Is(",") || s.Is(";") || s.Is(")") || s.Is("]") || s.Is("}"):
+			return
+		default:
+			s.Next()
+		}
+	}
+}
+
+// SkipGroup skips a bracketed group starting at the current opening token.
+func (s *Stream) SkipGroup(open, close string) {
+	depth := 0
+	for t := s.Peek(); t.Kind != EOF; t = s.Peek() {
+		s.Next()
+		if t.Kind != Punct {
+			continue
+		}
+		if t.Text == open {
+			depth++
+		} else if t.Text == close {
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tslex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string // Kind:Text of each token before EOF
+	}{
+		{`const a = 1;`, []string{"I:const", "I:a", "P:=", "N:1", "P:;"}},
+		{`x("it's", 'a\'b', ` + "`t`" + `)`, []string{"I:x", "P:(", "S:it's", "P:,", "S:a'b", "P:,", "S:t", "P:)"}},
+		{`a // note
+/* block */ b`, []string{"I:a", "I:b"}},
+		{`const r = /[/]x\//gi;`, []string{"I:const", "I:r", "P:=", "R:/[/]x\\//gi", "P:;"}},
+		{`a / b / c`, []string{"I:a", "P:/", "I:b", "P:/", "I:c"}},
+		{`[...xs].map((x) => x)`, []string{"P:[", "P:...", "I:xs", "P:]", "P:.", "I:map", "P:(", "P:(", "I:x", "P:)", "P:=>", "I:x", "P:)"}},
+		{`.5 + 1.25`, []string{"N:.5", "P:+", "N:1.25"}},
+	}
+	for _, tt := range tests {
+		tokens, err := Lex(tt.src)
+		if err != nil {
+			t.Errorf("Lex(%q): %v", tt.src, err)
+			continue
+		}
+		if got := describe(tokens); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("Lex(%q)\ngot  %v\nwant %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`"abc`, "unterminated string at offset 0"},
+		{`a = 'x\`, "unterminated string at offset 4"},
+		{`/* open`, "unterminated comment at offset 0"},
+		{"x = /ab\n/", "unterminated regular expression at offset 4"},
+		{`const y = (/`, "unterminated regular expression at offset 11"},
+		{`const y = (/a\`, "unterminated regular expression at offset 11"},
+		{`x = /[/`, "unterminated regular expression at offset 4"},
+	}
+	for _, tt := range tests {
+		_, err := Lex(tt.src)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Lex(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func FuzzLex(f *testing.F) {
+	for _, seed := range []string{
+		`const S = new Schema({ b: [Number] });`,
+		`interface I { a?: string[]; b: "x" | "y" }`,
+		`const r = /a[/]b/g; const t = ` + "`x`" + `;`,
+		`import a from "b"; // c`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		tokens, err := Lex(src)
+		if err != nil {
+			return
+		}
+		if len(tokens) == 0 || tokens[len(tokens)-1].Kind != EOF {
+			t.Fatalf("tokens do not end with EOF")
+		}
+		last := 0
+		for _, tok := range tokens {
+			if tok.Pos < last || tok.End < tok.Pos || tok.End > len(src) {
+				t.Fatalf("token %q has bad offsets %d..%d", tok.Text, tok.Pos, tok.End)
+			}
+			last = tok.End
+		}
+	})
+}
+
+func describe(tokens []Token) []string {
+	kinds := map[Kind]string{Ident: "I", String: "S", Number: "N", Regex: "R", Punct: "P"}
+	var out []string
+	for _, tok := range tokens {
+		if tok.Kind != EOF {
+			out = append(out, kinds[tok.Kind]+":"+tok.Text)
+		}
+	}
+	return out
+}
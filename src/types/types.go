@@ -1,14 +1,59 @@
 package types
 
+// Module is one entry of an upload file. Fields are given either as the
+// ModelProperties DSL string or as structured Fields, not both.
 type Module struct {
-	ModuleName      string `json:"moduleName"`
-	ModelProperties string `json:"modelProperties"`
+	ModuleName      string            `json:"moduleName" yaml:"moduleName"`
+	ModelProperties string            `json:"modelProperties,omitempty" yaml:"modelProperties,omitempty"`
+	Fields          []FieldDefinition `json:"fields,omitempty" yaml:"fields,omitempty"`
 }
 
+// FieldDefinition is the structured form of a field in upload files. Type
+// is a type code (S, N, ...) or its long name (string, number, boolean,
+// date, enum, ref, object), optionally suffixed with "[]" for a list.
+type FieldDefinition struct {
+	Name        string            `json:"name" yaml:"name"`
+	Type        string            `json:"type" yaml:"type"`
+	Required    bool              `json:"required,omitempty" yaml:"required,omitempty"`
+	Array       bool              `json:"array,omitempty" yaml:"array,omitempty"`
+	Default     any               `json:"default,omitempty" yaml:"default,omitempty"`
+	Enum        []string          `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Ref         string            `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Validations Validations       `json:"validations,omitzero" yaml:"validations,omitempty"`
+	Fields      []FieldDefinition `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Validations are the constraints of a FieldDefinition. Min and Max bound
+// numbers, or the length of strings.
+type Validations struct {
+	Min     *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max     *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+	Length  *float64 `json:"length,omitempty" yaml:"length,omitempty"`
+	Pattern string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Unique  bool     `json:"unique,omitempty" yaml:"unique,omitempty"`
+	Index   bool     `json:"index,omitempty" yaml:"index,omitempty"`
+}
+
+// Field is one property of a module, as parsed from a definition such as
+// "status@E(active|draft)@R@default(draft)".
 type Field struct {
 	Name     string
-	Type     string
+	Type     string // S, N, B, D, E (enum), REF, O (object) or a config type code
 	Required bool
+	Array    bool     // a list of Type, e.g. tags@S[]
+	Enum     []string // allowed values of an E field
+	Ref      string   // model name referenced by a REF field
+	Default  string   // default value as written; rendered per type
+	Min      string   // minimum value, or minimum length for strings
+	Max      string   // maximum value, or maximum length for strings
+	Length   string   // exact string length
+	Pattern  string   // regular expression a string must match
+	Unique   bool
+	Index    bool
+	Fields   []Field // properties of an O field
+	// Description is documentation carried into the generated code.
+	Description string
 }
 
 type FileInstruction struct {
@@ -24,11 +69,30 @@ type UpdateInstruction struct {
 	Position          string `json:"position"` // "top", "bottom", or empty (default top)
 	CreateIfNotExists bool   `json:"createIfNotExists"`
 	Description       string `json:"description,omitempty"`
+	// Block names the marker block the snippet is wrapped in; the module
+	// name is appended, as in "route:order". Defaults to the placeholder.
+	Block string `json:"block,omitempty"`
+	// Target is used when the file has no placeholder: "import" for the
+	// import list, or a top-level declaration such as "moduleRoutes" or
+	// "router.children" whose array, object or enum body gets the snippet.
+	Target string `json:"target,omitempty"`
 }
 
 type ProjectConfig struct {
-	Name               string              `json:"name"`
-	Version            string              `json:"version"`
-	FileInstructions   []FileInstruction   `json:"fileInstructions"`
-	UpdateInstructions []UpdateInstruction `json:"updateInstructions"`
-}
\ No newline at end of file
+	Name                   string                 `json:"name"`
+	Version                string                 `json:"version"`
+	FileInstructions       []FileInstruction      `json:"fileInstructions"`
+	UpdateInstructions     []UpdateInstruction    `json:"updateInstructions"`
+	InitFileInstructions   []FileInstruction      `json:"initFileInstructions,omitempty"`
+	InitUpdateInstructions []UpdateInstruction    `json:"initUpdateInstructions,omitempty"`
+	TypeMappings           map[string]TypeMapping `json:"typeMappings,omitempty"`
+	Defaults               map[string]string      `json:"defaults,omitempty"` // extra {{TOKEN}} values, keyed without braces
+}
+
+// TypeMapping overrides how a field type code (S, N, ...) is rendered by
+// each generator. Empty entries fall back to the built-in mapping.
+type TypeMapping struct {
+	TypeScript string `json:"typescript,omitempty"`
+	Mongoose   string `json:"mongoose,omitempty"`
+	Zod        string `json:"zod,omitempty"`
+}
@@ -0,0 +1,133 @@
+package verify
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Problem is an import that does not resolve to any of the checked files.
+type Problem struct {
+	File   string
+	Line   int
+	Import string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d: cannot resolve import %q", p.File, p.Line, p.Import)
+}
+
+var (
+	importPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?:import|export)\s[^;]*?\sfrom\s*["']([^"']+)["']`),
+		regexp.MustCompile(`import\s*["']([^"']+)["']`),
+		regexp.MustCompile(`(?:require|import)\(\s*["']([^"']+)["']\s*\)`),
+	}
+	sourceExtensions  = []string{".ts", ".tsx", ".js", ".jsx", ".mts", ".cts"}
+	resolveExtensions = []string{"", ".ts", ".tsx", ".d.ts", ".js", ".jsx", ".json"}
+)
+
+// Imports checks that every relative ("./", "../") and aliased import in
+// the source files of files resolves to another entry of files. files maps
+// slash-separated paths, relative to the project root, to their content.
+// aliases maps an import prefix such as "@/" to the directory it points at,
+// e.g. "src/". Bare package imports are assumed to come from node_modules
+// and are not checked. It returns the problems found and the number of
+// imports checked.
+func Imports(files map[string]string, aliases map[string]string) ([]Problem, int) {
+	known := make(map[string]bool, len(files))
+	for name := range files {
+		known[path.Clean(name)] = true
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if isSource(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var problems []Problem
+	checked := 0
+	for _, name := range names {
+		for _, ref := range findImports(files[name]) {
+			target, ok := importTarget(path.Clean(name), ref.spec, aliases)
+			if !ok {
+				continue
+			}
+			checked++
+			if !resolves(target, known) {
+				problems = append(problems, Problem{File: name, Line: ref.line, Import: ref.spec})
+			}
+		}
+	}
+	return problems, checked
+}
+
+type importRef struct {
+	spec string
+	line int
+}
+
+func findImports(content string) []importRef {
+	var refs []importRef
+	seen := map[int]bool{}
+	for _, pattern := range importPatterns {
+		for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+			start := match[2]
+			if seen[start] {
+				continue
+			}
+			seen[start] = true
+			refs = append(refs, importRef{
+				spec: content[match[2]:match[3]],
+				line: strings.Count(content[:start], "\n") + 1,
+			})
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].line < refs[j].line })
+	return refs
+}
+
+// importTarget maps an import specifier to a project path, or reports false
+// for package imports that are not checked.
+func importTarget(from, spec string, aliases map[string]string) (string, bool) {
+	if strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../") {
+		return path.Join(path.Dir(from), spec), true
+	}
+	for prefix, dir := range aliases {
+		if strings.HasPrefix(spec, prefix) {
+			return path.Join(dir, strings.TrimPrefix(spec, prefix)), true
+		}
+	}
+	return "", false
+}
+
+func resolves(target string, known map[string]bool) bool {
+	for _, ext := range resolveExtensions {
+		if known[target+ext] {
+			return true
+		}
+	}
+	for _, ext := range sourceExtensions {
+		if known[path.Join(target, "index"+ext)] {
+			return true
+		}
+	}
+	return false
+}
+
+func isSource(name string) bool {
+	if strings.HasSuffix(name, ".d.ts") {
+		return true
+	}
+	for _, ext := range sourceExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}